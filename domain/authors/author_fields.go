@@ -0,0 +1,6 @@
+package authors
+
+import "github.com/Henrod/library/domain/resources"
+
+// authorKind describes the authors to the use cases shared by the resources.
+var authorKind = resources.Named("author")
@@ -0,0 +1,43 @@
+package authors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Henrod/library/domain/entities"
+)
+
+type CreateAuthorDomain struct {
+	gateway CreateAuthorGateway
+}
+
+func NewCreateAuthorDomain(gateway CreateAuthorGateway) *CreateAuthorDomain {
+	return &CreateAuthorDomain{gateway: gateway}
+}
+
+// CreateAuthorGateway creates the author.
+// If the author already exists, returns nil author and nil error.
+type CreateAuthorGateway interface {
+	CreateAuthor(ctx context.Context, author *entities.Author) (*entities.Author, error)
+}
+
+func (c *CreateAuthorDomain) CreateAuthor(ctx context.Context, inputAuthor *entities.Author) (*entities.Author, error) {
+	if err := authorKind.ValidateID(inputAuthor.Name); err != nil {
+		return nil, err
+	}
+
+	if err := authorKind.ValidateDisplayName(inputAuthor.DisplayName); err != nil {
+		return nil, err
+	}
+
+	author, err := c.gateway.CreateAuthor(ctx, inputAuthor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create author in gateway: %w", err)
+	}
+
+	if author == nil {
+		return nil, authorKind.AlreadyExists(inputAuthor.Name)
+	}
+
+	return author, nil
+}
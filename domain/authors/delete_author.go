@@ -0,0 +1,35 @@
+package authors
+
+import (
+	"context"
+	"fmt"
+)
+
+type DeleteAuthorDomain struct {
+	gateway DeleteAuthorGateway
+}
+
+func NewDeleteAuthorDomain(gateway DeleteAuthorGateway) *DeleteAuthorDomain {
+	return &DeleteAuthorDomain{gateway: gateway}
+}
+
+// DeleteAuthorGateway removes the author, returning false if it is not found.
+// If any book is linked to the author, including deleted books not purged yet, returns FailedPreconditionError.
+type DeleteAuthorGateway interface {
+	DeleteAuthor(ctx context.Context, authorName string) (bool, error)
+}
+
+// DeleteAuthor removes the author from the library.
+// Its books must be linked to another author or unlinked first, so no book refers to a missing author.
+func (d *DeleteAuthorDomain) DeleteAuthor(ctx context.Context, authorName string) error {
+	deleted, err := d.gateway.DeleteAuthor(ctx, authorName)
+	if err != nil {
+		return fmt.Errorf("failed to delete author in gateway: %w", err)
+	}
+
+	if !deleted {
+		return authorKind.NotFound(authorName)
+	}
+
+	return nil
+}
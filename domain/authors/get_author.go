@@ -0,0 +1,35 @@
+package authors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Henrod/library/domain/entities"
+)
+
+type GetAuthorDomain struct {
+	gateway GetAuthorGateway
+}
+
+func NewGetAuthorDomain(gateway GetAuthorGateway) *GetAuthorDomain {
+	return &GetAuthorDomain{gateway: gateway}
+}
+
+// GetAuthorGateway returns the author of name.
+// If the author is not found, returns nil author and nil error.
+type GetAuthorGateway interface {
+	GetAuthor(ctx context.Context, authorName string) (*entities.Author, error)
+}
+
+func (g *GetAuthorDomain) GetAuthor(ctx context.Context, authorName string) (*entities.Author, error) {
+	author, err := g.gateway.GetAuthor(ctx, authorName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get author from gateway: %w", err)
+	}
+
+	if author == nil {
+		return nil, authorKind.NotFound(authorName)
+	}
+
+	return author, nil
+}
@@ -0,0 +1,64 @@
+package authors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/filter"
+	"github.com/Henrod/library/domain/orderby"
+	"github.com/Henrod/library/domain/pagination"
+)
+
+type ListAuthorsDomain struct {
+	gateway ListAuthorsGateway
+}
+
+func NewListAuthorsDomain(gateway ListAuthorsGateway) *ListAuthorsDomain {
+	return &ListAuthorsDomain{gateway: gateway}
+}
+
+// ListAuthorsGateway lists the authors of page sorted by ordering.
+type ListAuthorsGateway interface {
+	ListAuthors(
+		ctx context.Context,
+		expr filter.Expr,
+		ordering []orderby.Field,
+		page pagination.Page,
+	) ([]*entities.Author, error)
+}
+
+// List returns the authors matching the AIP-160 filter sorted by the AIP-132 order_by.
+// Empty filter matches every author and empty order_by sorts by author name.
+//
+// The page starts right after its cursor, which is nil for the first page.
+// The returned cursor points to the last author of the page and is nil when there are no more pages.
+func (l *ListAuthorsDomain) List(
+	ctx context.Context,
+	filterExpr, orderBy string,
+	page pagination.Page,
+) (authors []*entities.Author, next pagination.Cursor, err error) {
+	query, err := authorKind.ParseList(filterExpr, orderBy, page)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	authors, err = l.gateway.ListAuthors(ctx, query.Expr, query.Ordering, query.Page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list authors in gateway: %w", err)
+	}
+
+	if len(authors) <= page.Size {
+		return authors, nil, nil
+	}
+
+	authors = authors[:page.Size]
+	last := authors[len(authors)-1]
+
+	return authors, query.Cursor(map[string]interface{}{
+		"name":         last.Name,
+		"display_name": last.DisplayName,
+		"create_time":  last.CreateTime,
+		"update_time":  last.UpdateTime,
+	}), nil
+}
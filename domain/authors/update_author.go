@@ -0,0 +1,50 @@
+package authors
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/Henrod/library/domain/entities"
+)
+
+type UpdateAuthorDomain struct {
+	gateway UpdateAuthorGateway
+}
+
+func NewUpdateAuthorDomain(gateway UpdateAuthorGateway) *UpdateAuthorDomain {
+	return &UpdateAuthorDomain{gateway: gateway}
+}
+
+// UpdateAuthorGateway updates only the author fields in fields.
+// If the author is not found, returns nil author and nil error.
+type UpdateAuthorGateway interface {
+	UpdateAuthor(ctx context.Context, author *entities.Author, fields []string) (*entities.Author, error)
+}
+
+func (u *UpdateAuthorDomain) UpdateAuthor(
+	ctx context.Context,
+	inputAuthor *entities.Author,
+	updateMask *fieldmaskpb.FieldMask,
+) (*entities.Author, error) {
+	fields, err := authorKind.UpdateFields(updateMask)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorKind.ValidateDisplayName(inputAuthor.DisplayName); err != nil {
+		return nil, err
+	}
+
+	author, err := u.gateway.UpdateAuthor(ctx, inputAuthor, fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update author in gateway: %w", err)
+	}
+
+	if author == nil {
+		return nil, authorKind.NotFound(inputAuthor.Name)
+	}
+
+	return author, nil
+}
@@ -0,0 +1,115 @@
+package books
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/errors"
+)
+
+type BatchCreateBooksDomain struct {
+	gateway BatchCreateBooksGateway
+}
+
+func NewBatchCreateBooksDomain(gateway BatchCreateBooksGateway) *BatchCreateBooksDomain {
+	return &BatchCreateBooksDomain{gateway: gateway}
+}
+
+// BatchCreateBooksGateway creates every book in shelf or none of them.
+// If any book already exists, returns nil books and nil error.
+// If shelf or the author, series or work of any book doesn't exist, returns ErrShelfNotFound,
+// ErrAuthorNotFound, ErrSeriesNotFound or ErrWorkNotFound.
+type BatchCreateBooksGateway interface {
+	BatchGetBooksGateway
+	CreateBooks(ctx context.Context, shelfName string, books []*entities.Book) ([]*entities.Book, error)
+}
+
+// BatchCreateBooks creates the books in shelf atomically, returning them in the same order.
+// Book IDs are generated for books without name.
+// Every book is validated before any of them is created, and the first invalid book fails the batch
+// with its index: BadRequestError if its ID or fields are invalid or its ID is repeated in the batch
+// and AlreadyExistsError if it exists.
+func (b *BatchCreateBooksDomain) BatchCreateBooks(
+	ctx context.Context,
+	shelfName string,
+	inputBooks []*entities.Book,
+) ([]*entities.Book, error) {
+	if err := validateBatchSize("requests", len(inputBooks)); err != nil {
+		return nil, err
+	}
+
+	newBooks := make([]*entities.Book, len(inputBooks))
+	bookNames := make([]string, len(inputBooks))
+	indexes := make(map[string]int, len(inputBooks))
+
+	for i, inputBook := range inputBooks {
+		bookName, err := bookIDOrNew(fmt.Sprintf("requests[%d].book_id", i), inputBook.Name)
+		if err != nil {
+			return nil, errors.BatchItemError{Index: i, Err: err}
+		}
+
+		namedBook := *inputBook
+		namedBook.Name = bookName
+
+		book, err := validateBookFields(fmt.Sprintf("requests[%d].book.", i), &namedBook, replacedFields)
+		if err != nil {
+			return nil, errors.BatchItemError{Index: i, Err: err}
+		}
+
+		newBooks[i] = book
+
+		if _, ok := indexes[book.Name]; ok {
+			return nil, errors.BatchItemError{
+				Index: i,
+				Err: &errors.BadRequestError{
+					InvalidField: fmt.Sprintf("requests[%d].book_id", i),
+					Details:      fmt.Sprintf("book %s is repeated in the batch", book.Name),
+				},
+			}
+		}
+
+		indexes[book.Name] = i
+		bookNames[i] = book.Name
+	}
+
+	// Deleted books keep their names until they are purged.
+	existingBooks, err := b.gateway.GetBooks(ctx, shelfName, bookNames, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get books from gateway: %w", err)
+	}
+
+	if len(existingBooks) > 0 {
+		firstIndex := len(inputBooks)
+		for _, book := range existingBooks {
+			if i := indexes[book.Name]; i < firstIndex {
+				firstIndex = i
+			}
+		}
+
+		return nil, errors.BatchItemError{
+			Index: firstIndex,
+			Err: errors.AlreadyExistsError{
+				Details: fmt.Sprintf("book %s at shelf %s already exists", bookNames[firstIndex], shelfName),
+			},
+		}
+	}
+
+	books, err := b.gateway.CreateBooks(ctx, shelfName, newBooks)
+	if notFound := referenceNotFound(err, shelfName, nil); notFound != nil {
+		return nil, notFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create books in gateway: %w", err)
+	}
+
+	if books == nil {
+		// A book was created concurrently after the validation.
+		return nil, errors.AlreadyExistsError{
+			Details: fmt.Sprintf("a book of the batch already exists at shelf %s", shelfName),
+		}
+	}
+
+	return books, nil
+}
@@ -0,0 +1,70 @@
+package books
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Henrod/library/domain/errors"
+)
+
+type BatchDeleteBooksDomain struct {
+	gateway   BatchDeleteBooksGateway
+	retention time.Duration
+}
+
+// NewBatchDeleteBooksDomain returns the BatchDeleteBooksDomain.
+// Deleted books are kept for retention before they are purged.
+func NewBatchDeleteBooksDomain(gateway BatchDeleteBooksGateway, retention time.Duration) *BatchDeleteBooksDomain {
+	return &BatchDeleteBooksDomain{gateway: gateway, retention: retention}
+}
+
+// BatchDeleteBooksGateway deletes every book of names from shelf or none of them.
+// Returns false if any book doesn't exist.
+type BatchDeleteBooksGateway interface {
+	BatchGetBooksGateway
+	DeleteBooks(ctx context.Context, shelfName string, bookNames []string, purgeTime time.Time) (bool, error)
+}
+
+// BatchDeleteBooks soft deletes the books from shelf atomically.
+// If any book doesn't exist, nothing is deleted and returns NotFoundError of the first missing book with its index.
+func (b *BatchDeleteBooksDomain) BatchDeleteBooks(ctx context.Context, shelfName string, bookNames []string) error {
+	if err := validateBatchSize("names", len(bookNames)); err != nil {
+		return err
+	}
+
+	existingBooks, err := b.gateway.GetBooks(ctx, shelfName, bookNames, false)
+	if err != nil {
+		return fmt.Errorf("failed to get books from gateway: %w", err)
+	}
+
+	existing := make(map[string]struct{}, len(existingBooks))
+	for _, book := range existingBooks {
+		existing[book.Name] = struct{}{}
+	}
+
+	for i, bookName := range bookNames {
+		if _, ok := existing[bookName]; !ok {
+			return errors.BatchItemError{
+				Index: i,
+				Err: errors.NotFoundError{
+					Details: fmt.Sprintf("book %s at shelf %s not found", bookName, shelfName),
+				},
+			}
+		}
+	}
+
+	deleted, err := b.gateway.DeleteBooks(ctx, shelfName, bookNames, time.Now().Add(b.retention))
+	if err != nil {
+		return fmt.Errorf("failed to delete books in gateway: %w", err)
+	}
+
+	if !deleted {
+		// A book was deleted concurrently after the validation.
+		return errors.NotFoundError{
+			Details: fmt.Sprintf("a book of the batch was not found at shelf %s", shelfName),
+		}
+	}
+
+	return nil
+}
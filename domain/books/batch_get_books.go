@@ -0,0 +1,85 @@
+package books
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/errors"
+)
+
+// maxBatchSize is the maximum number of books in a batch request.
+const maxBatchSize = 1000
+
+type BatchGetBooksDomain struct {
+	gateway BatchGetBooksGateway
+}
+
+func NewBatchGetBooksDomain(gateway BatchGetBooksGateway) *BatchGetBooksDomain {
+	return &BatchGetBooksDomain{gateway: gateway}
+}
+
+// BatchGetBooksGateway returns the books of names from shelf in a single query.
+// Books not found, or deleted unless showDeleted is true, are absent from the result,
+// which has no particular order.
+type BatchGetBooksGateway interface {
+	GetBooks(ctx context.Context, shelfName string, bookNames []string, showDeleted bool) ([]*entities.Book, error)
+}
+
+// BatchGetBooks returns the books in the same order as bookNames.
+// If any book doesn't exist, returns NotFoundError of the first missing book with its index.
+func (b *BatchGetBooksDomain) BatchGetBooks(
+	ctx context.Context,
+	shelfName string,
+	bookNames []string,
+) ([]*entities.Book, error) {
+	if err := validateBatchSize("names", len(bookNames)); err != nil {
+		return nil, err
+	}
+
+	books, err := b.gateway.GetBooks(ctx, shelfName, bookNames, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get books from gateway: %w", err)
+	}
+
+	booksByName := make(map[string]*entities.Book, len(books))
+	for _, book := range books {
+		booksByName[book.Name] = book
+	}
+
+	result := make([]*entities.Book, len(bookNames))
+
+	for i, bookName := range bookNames {
+		book, ok := booksByName[bookName]
+		if !ok {
+			return nil, errors.BatchItemError{
+				Index: i,
+				Err: errors.NotFoundError{
+					Details: fmt.Sprintf("book %s at shelf %s not found", bookName, shelfName),
+				},
+			}
+		}
+
+		result[i] = book
+	}
+
+	return result, nil
+}
+
+func validateBatchSize(field string, size int) error {
+	if size == 0 {
+		return &errors.BadRequestError{
+			InvalidField: field,
+			Details:      fmt.Sprintf("%s must not be empty", field),
+		}
+	}
+
+	if size > maxBatchSize {
+		return &errors.BadRequestError{
+			InvalidField: field,
+			Details:      fmt.Sprintf("%s must have at most %d items", field, maxBatchSize),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,227 @@
+package books
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/language"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/errors"
+	"github.com/Henrod/library/domain/labels"
+)
+
+const (
+	// maxTextLength is the exclusive limit of characters of the free text book fields.
+	maxTextLength = 255
+	// maxDescriptionLength is the exclusive limit of characters of the book description.
+	maxDescriptionLength = 10000
+	// maxContributors is the maximum number of contributors of a book.
+	maxContributors = 100
+)
+
+// contributorRoles are the valid roles of a book contributor.
+var contributorRoles = map[entities.ContributorRole]struct{}{
+	entities.ContributorRoleAuthor:      {},
+	entities.ContributorRoleEditor:      {},
+	entities.ContributorRoleTranslator:  {},
+	entities.ContributorRoleIllustrator: {},
+}
+
+// validateBookFields validates the fields of book and returns a copy of it with isbn and language normalized,
+// so book is not modified. Field violations are reported as prefix + field, e.g. "book.isbn".
+func validateBookFields(prefix string, book *entities.Book, fields []string) (*entities.Book, error) {
+	validBook := *book
+
+	for _, field := range fields {
+		var err error
+
+		switch field {
+		case "contributors":
+			err = validateContributors(prefix+field, book.Contributors)
+		case legacyAuthorPath:
+			err = validateText(prefix+field, book.Author(), maxTextLength)
+		case "title":
+			err = validateText(prefix+field, book.Title, maxTextLength)
+		case "publisher":
+			err = validateText(prefix+field, book.Publisher, maxTextLength)
+		case "description":
+			err = validateText(prefix+field, book.Description, maxDescriptionLength)
+		case "isbn":
+			validBook.ISBN, err = normalizeISBN(prefix+field, book.ISBN)
+		case "publication_year":
+			err = validatePublicationYear(prefix+field, book.PublicationYear)
+		case "language":
+			validBook.Language, err = normalizeLanguage(prefix+field, book.Language)
+		case "labels":
+			err = labels.Validate(prefix+field, book.Labels)
+		case "volume_number":
+			if book.VolumeNumber < 0 {
+				err = &errors.BadRequestError{
+					InvalidField: prefix + field,
+					Details:      fmt.Sprintf("%s%s must not be negative", prefix, field),
+				}
+			}
+		case "page_count":
+			if book.PageCount < 0 {
+				err = &errors.BadRequestError{
+					InvalidField: prefix + field,
+					Details:      fmt.Sprintf("%s%s must not be negative", prefix, field),
+				}
+			}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &validBook, nil
+}
+
+// validateText requires text to have less than maxLength characters, an exclusive limit.
+func validateText(field, text string, maxLength int) error {
+	if utf8.RuneCountInString(text) >= maxLength {
+		return &errors.BadRequestError{
+			InvalidField: field,
+			Details:      fmt.Sprintf("%s must have less than %d characters", field, maxLength),
+		}
+	}
+
+	return nil
+}
+
+// validateContributors requires a name and a known role for every contributor.
+func validateContributors(field string, contributors []*entities.Contributor) error {
+	if len(contributors) > maxContributors {
+		return &errors.BadRequestError{
+			InvalidField: field,
+			Details:      fmt.Sprintf("%s must have at most %d contributors", field, maxContributors),
+		}
+	}
+
+	for i, contributor := range contributors {
+		contributorField := fmt.Sprintf("%s[%d]", field, i)
+
+		if strings.TrimSpace(contributor.Name) == "" {
+			return &errors.BadRequestError{
+				InvalidField: contributorField + ".name",
+				Details:      fmt.Sprintf("%s.name must not be empty", contributorField),
+			}
+		}
+
+		if err := validateText(contributorField+".name", contributor.Name, maxTextLength); err != nil {
+			return err
+		}
+
+		if _, ok := contributorRoles[contributor.Role]; !ok {
+			return &errors.BadRequestError{
+				InvalidField: contributorField + ".role",
+				Details:      fmt.Sprintf("%s.role must be AUTHOR, EDITOR, TRANSLATOR or ILLUSTRATOR", contributorField),
+			}
+		}
+	}
+
+	return nil
+}
+
+// validatePublicationYear accepts unknown (zero) years and years until the current one.
+func validatePublicationYear(field string, year int) error {
+	if year < 0 || year > time.Now().Year() {
+		return &errors.BadRequestError{
+			InvalidField: field,
+			Details:      fmt.Sprintf("%s must be between 0 and the current year", field),
+		}
+	}
+
+	return nil
+}
+
+// normalizeISBN removes hyphens and spaces from isbn and validates its check digit.
+// Empty isbn is valid, meaning the book has no ISBN.
+func normalizeISBN(field, isbn string) (string, error) {
+	normalized := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(isbn))
+
+	var valid bool
+
+	switch len(normalized) {
+	case 0:
+		return "", nil
+	case 10: //nolint:gomnd
+		valid = isValidISBN10(normalized)
+	case 13: //nolint:gomnd
+		valid = isValidISBN13(normalized)
+	}
+
+	if !valid {
+		return "", &errors.BadRequestError{
+			InvalidField: field,
+			Details:      fmt.Sprintf("%s must be a valid ISBN-10 or ISBN-13", field),
+		}
+	}
+
+	return normalized, nil
+}
+
+// isValidISBN10 checks that the digits weighted from 10 to 1 sum to a multiple of 11.
+// The check digit 'X' means 10.
+func isValidISBN10(isbn string) bool {
+	sum := 0
+
+	for i, r := range isbn {
+		var digit int
+
+		switch {
+		case r >= '0' && r <= '9':
+			digit = int(r - '0')
+		case r == 'X' && i == len(isbn)-1:
+			digit = 10
+		default:
+			return false
+		}
+
+		sum += (len(isbn) - i) * digit
+	}
+
+	return sum%11 == 0
+}
+
+// isValidISBN13 checks that the digits weighted alternately by 1 and 3 sum to a multiple of 10.
+func isValidISBN13(isbn string) bool {
+	sum := 0
+
+	for i, r := range isbn {
+		if r < '0' || r > '9' {
+			return false
+		}
+
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+
+		sum += weight * int(r-'0')
+	}
+
+	return sum%10 == 0
+}
+
+// normalizeLanguage returns the canonical form of the BCP-47 tag, e.g. "pt-br" becomes "pt-BR".
+// Empty tag is valid, meaning the language is unknown.
+func normalizeLanguage(field, tag string) (string, error) {
+	if tag == "" {
+		return "", nil
+	}
+
+	parsed, err := language.Parse(tag)
+	if err != nil {
+		return "", &errors.BadRequestError{
+			InvalidField: field,
+			Details:      fmt.Sprintf("%s must be a BCP-47 language tag, e.g. \"en\" or \"pt-BR\"", field),
+		}
+	}
+
+	return parsed.String(), nil
+}
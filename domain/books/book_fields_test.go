@@ -0,0 +1,30 @@
+package books
+
+import (
+	"testing"
+
+	"github.com/Henrod/library/domain/entities"
+)
+
+func TestValidateBookFieldsDoesNotModifyBook(t *testing.T) {
+	t.Parallel()
+
+	book := &entities.Book{ //nolint:exhaustivestruct
+		Name:     "book1",
+		ISBN:     "978-0-306-40615-7",
+		Language: "PT-br",
+	}
+
+	validBook, err := validateBookFields("book.", book, replacedFields)
+	if err != nil {
+		t.Fatalf("validateBookFields returned %v", err)
+	}
+
+	if validBook.ISBN != "9780306406157" || validBook.Language != "pt-BR" {
+		t.Errorf("validateBookFields = isbn %q, language %q, want them normalized", validBook.ISBN, validBook.Language)
+	}
+
+	if book.ISBN != "978-0-306-40615-7" || book.Language != "PT-br" {
+		t.Errorf("validateBookFields modified the book to isbn %q, language %q", book.ISBN, book.Language)
+	}
+}
@@ -0,0 +1,38 @@
+package books
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"strings"
+
+	"github.com/Henrod/library/domain/resourcename"
+)
+
+// generatedBookIDBytes are random enough to be unique without checking the existing books.
+const generatedBookIDBytes = 16
+
+var bookIDEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// newBookID generates a unique URL-safe book ID.
+func newBookID() (string, error) {
+	b := make([]byte, generatedBookIDBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random book id: %w", err)
+	}
+
+	return strings.ToLower(bookIDEncoding.EncodeToString(b)), nil
+}
+
+// bookIDOrNew validates bookID if it was supplied, otherwise returns a new one.
+func bookIDOrNew(field, bookID string) (string, error) {
+	if bookID == "" {
+		return newBookID()
+	}
+
+	if err := resourcename.ValidateID(field, bookID); err != nil {
+		return "", fmt.Errorf("invalid book id: %w", err)
+	}
+
+	return bookID, nil
+}
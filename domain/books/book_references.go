@@ -0,0 +1,63 @@
+package books
+
+import (
+	stderrors "errors"
+	"fmt"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/errors"
+)
+
+// The gateways return these errors when a book can't be written because of the resources it refers to,
+// and the use cases turn them into the respective domain errors.
+var (
+	// ErrShelfNotFound is returned when the shelf of the book doesn't exist.
+	ErrShelfNotFound = stderrors.New("shelf not found")
+	// ErrAuthorNotFound is returned when the author the book is linked to doesn't exist.
+	ErrAuthorNotFound = stderrors.New("author not found")
+	// ErrSeriesNotFound is returned when the series of the book doesn't exist.
+	ErrSeriesNotFound = stderrors.New("series not found")
+	// ErrWorkNotFound is returned when the work the book is an edition of doesn't exist.
+	ErrWorkNotFound = stderrors.New("work not found")
+	// ErrBookExists is returned when the shelf already has a book with the same name.
+	ErrBookExists = stderrors.New("book already exists")
+)
+
+// referenceNotFound returns the NotFoundError of the resource a book in shelf refers to
+// if err tells it doesn't exist, or nil otherwise.
+// The book is nil for batches, as the gateway doesn't tell which of their books refers to the resource.
+func referenceNotFound(err error, shelfName string, book *entities.Book) error {
+	var noun, name string
+
+	switch {
+	case stderrors.Is(err, ErrShelfNotFound):
+		noun, name = "shelf", shelfName
+	case stderrors.Is(err, ErrAuthorNotFound):
+		noun = "author"
+		if book != nil {
+			name = book.AuthorName
+		}
+	case stderrors.Is(err, ErrSeriesNotFound):
+		noun = "series"
+		if book != nil {
+			name = book.SeriesName
+		}
+	case stderrors.Is(err, ErrWorkNotFound):
+		noun = "work"
+		if book != nil {
+			name = book.WorkName
+		}
+	default:
+		return nil
+	}
+
+	if name == "" {
+		return errors.NotFoundError{
+			Details: fmt.Sprintf("%s of a book not found", noun),
+		}
+	}
+
+	return errors.NotFoundError{
+		Details: fmt.Sprintf("%s %s not found", noun, name),
+	}
+}
@@ -16,17 +16,40 @@ func NewCreateBookDomain(gateway CreateBookGateway) *CreateBookDomain {
 	return &CreateBookDomain{gateway: gateway}
 }
 
+// CreateBookGateway creates a book in shelf.
+// If the book already exists, returns nil book and nil error.
+// If shelf or the book author, series or work doesn't exist, returns ErrShelfNotFound, ErrAuthorNotFound,
+// ErrSeriesNotFound or ErrWorkNotFound.
 type CreateBookGateway interface {
 	CreateBook(ctx context.Context, shelfName string, book *entities.Book) (*entities.Book, error)
 }
 
+// CreateBook creates the book in shelf.
+// The book name is its ID, which is generated if empty, otherwise it must match the book ID pattern.
+// The isbn and language of the created book are normalized, without modifying inputBook.
 func (c *CreateBookDomain) CreateBook(
 	ctx context.Context,
 	shelfName string,
 	inputBook *entities.Book,
 ) (*entities.Book, error) {
-	bookName := inputBook.Name
-	book, err := c.gateway.CreateBook(ctx, shelfName, inputBook)
+	bookName, err := bookIDOrNew("book_id", inputBook.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	namedBook := *inputBook
+	namedBook.Name = bookName
+
+	newBook, err := validateBookFields("book.", &namedBook, replacedFields)
+	if err != nil {
+		return nil, err
+	}
+
+	book, err := c.gateway.CreateBook(ctx, shelfName, newBook)
+	if notFound := referenceNotFound(err, shelfName, newBook); notFound != nil {
+		return nil, notFound
+	}
+
 	if err != nil {
 		return nil, fmt.Errorf("failed to create book in gateway: %w", err)
 	}
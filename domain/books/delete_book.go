@@ -3,32 +3,35 @@ package books
 import (
 	"context"
 	"fmt"
-
-	"github.com/Henrod/library/domain/errors"
+	"time"
 )
 
 type DeleteBookDomain struct {
-	gateway DeleteBookGateway
+	gateway   DeleteBookGateway
+	retention time.Duration
 }
 
-func NewDeleteBookDomain(gateway DeleteBookGateway) *DeleteBookDomain {
-	return &DeleteBookDomain{gateway: gateway}
+// NewDeleteBookDomain returns the DeleteBookDomain.
+// Deleted books are kept for retention before they are purged.
+func NewDeleteBookDomain(gateway DeleteBookGateway, retention time.Duration) *DeleteBookDomain {
+	return &DeleteBookDomain{gateway: gateway, retention: retention}
 }
 
 type DeleteBookGateway interface {
-	DeleteBook(ctx context.Context, shelfName, bookName string) (bool, error)
+	GetBookGateway
+	DeleteBook(ctx context.Context, shelfName, bookName string, version int64, purgeTime time.Time) (bool, error)
 }
 
-func (g *DeleteBookDomain) DeleteBook(ctx context.Context, shelfName, bookName string) error {
-	deleted, err := g.gateway.DeleteBook(ctx, shelfName, bookName)
+// DeleteBook soft deletes the book, which can be undeleted until it is purged.
+// If version is not zero, it must be the current book version, otherwise returns AbortedError.
+func (g *DeleteBookDomain) DeleteBook(ctx context.Context, shelfName, bookName string, version int64) error {
+	deleted, err := g.gateway.DeleteBook(ctx, shelfName, bookName, version, time.Now().Add(g.retention))
 	if err != nil {
-		return fmt.Errorf("failed to get book from gateway: %w", err)
+		return fmt.Errorf("failed to delete book in gateway: %w", err)
 	}
 
 	if !deleted {
-		return errors.NotFoundError{
-			Details: fmt.Sprintf("book %s at shelf %s not found", shelfName, bookName),
-		}
+		return bookNotChangedError(ctx, g.gateway, shelfName, bookName, version)
 	}
 
 	return nil
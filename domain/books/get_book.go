@@ -18,11 +18,16 @@ func NewGetBookDomain(gateway GetBookGateway) *GetBookDomain {
 }
 
 type GetBookGateway interface {
-	GetBook(ctx context.Context, shelfName, bookName string) (*entities.Book, error)
+	GetBook(ctx context.Context, shelfName, bookName string, showDeleted bool) (*entities.Book, error)
 }
 
-func (g *GetBookDomain) GetBook(ctx context.Context, shelfName, bookName string) (*entities.Book, error) {
-	book, err := g.gateway.GetBook(ctx, shelfName, bookName)
+// GetBook returns the book, which is not found if deleted unless showDeleted is true.
+func (g *GetBookDomain) GetBook(
+	ctx context.Context,
+	shelfName, bookName string,
+	showDeleted bool,
+) (*entities.Book, error) {
+	book, err := g.gateway.GetBook(ctx, shelfName, bookName, showDeleted)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get book from gateway: %w", err)
 	}
@@ -34,10 +39,25 @@ func (g *GetBookDomain) GetBook(ctx context.Context, shelfName, bookName string)
 	}
 
 	return &entities.Book{
-		Name:       book.Name,
-		Author:     book.Author,
-		Shelf:      book.Shelf,
-		CreateTime: book.CreateTime,
-		UpdateTime: book.UpdateTime,
+		Name:            book.Name,
+		Contributors:    book.Contributors,
+		AuthorName:      book.AuthorName,
+		SeriesName:      book.SeriesName,
+		VolumeNumber:    book.VolumeNumber,
+		WorkName:        book.WorkName,
+		Title:           book.Title,
+		ISBN:            book.ISBN,
+		Publisher:       book.Publisher,
+		PublicationYear: book.PublicationYear,
+		Language:        book.Language,
+		PageCount:       book.PageCount,
+		Labels:          book.Labels,
+		Description:     book.Description,
+		Shelf:           book.Shelf,
+		CreateTime:      book.CreateTime,
+		UpdateTime:      book.UpdateTime,
+		DeleteTime:      book.DeleteTime,
+		PurgeTime:       book.PurgeTime,
+		Version:         book.Version,
 	}, nil
 }
@@ -0,0 +1,50 @@
+package books
+
+import (
+	"context"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/orderby"
+	"github.com/Henrod/library/domain/pagination"
+)
+
+// authorBooks sorts the author books by shelf and name, as books are listed by default.
+var authorBooks = parentBooks{
+	noun:     "author",
+	request:  "list author books",
+	ordering: orderby.WithTiebreakers(nil, tiebreakerFields...),
+}
+
+type ListAuthorBooksDomain struct {
+	gateway ListAuthorBooksGateway
+}
+
+func NewListAuthorBooksDomain(gateway ListAuthorBooksGateway) *ListAuthorBooksDomain {
+	return &ListAuthorBooksDomain{gateway: gateway}
+}
+
+// ListAuthorBooksGateway lists the not deleted books linked to the author, from every shelf.
+type ListAuthorBooksGateway interface {
+	GetAuthor(ctx context.Context, authorName string) (*entities.Author, error)
+	ListAuthorBooks(
+		ctx context.Context,
+		authorName string,
+		ordering []orderby.Field,
+		page pagination.Page,
+	) ([]*entities.Book, error)
+}
+
+// List returns the books of the author across every shelf, sorted by shelf and book name.
+func (l *ListAuthorBooksDomain) List(
+	ctx context.Context,
+	authorName string,
+	page pagination.Page,
+) (books []*entities.Book, next pagination.Cursor, err error) {
+	findAuthor := func(ctx context.Context, authorName string) (bool, error) {
+		author, err := l.gateway.GetAuthor(ctx, authorName)
+
+		return author != nil, err //nolint:wrapcheck
+	}
+
+	return authorBooks.list(ctx, authorName, page, findAuthor, l.gateway.ListAuthorBooks)
+}
@@ -5,78 +5,183 @@ import (
 	"fmt"
 
 	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/errors"
+	"github.com/Henrod/library/domain/filter"
+	"github.com/Henrod/library/domain/orderby"
+	"github.com/Henrod/library/domain/pagination"
 )
 
 type ListBooksDomain struct {
 	gateway ListBooksGateway
 }
 
+// filterableFields are the book fields accepted in the list filter.
+// The contributor field is the name of any contributor, and contributors.{role} of a contributor with the role.
+// The author_name, series and work fields are resource names, as in the book, e.g. "authors/henrod".
+var filterableFields = filter.Fields{
+	"name":             filter.String,
+	"author":           filter.String,
+	"title":            filter.String,
+	"isbn":             filter.String,
+	"publisher":        filter.String,
+	"publication_year": filter.Integer,
+	"language":         filter.String,
+	"page_count":       filter.Integer,
+	"create_time":      filter.Timestamp,
+	"update_time":      filter.Timestamp,
+	"labels":           filter.Map,
+	"contributor":      filter.String,
+	"contributors":     filter.Map,
+	"author_name":      filter.String,
+	"series":           filter.String,
+	"work":             filter.String,
+}
+
+// sortableFields are the book fields accepted in the list order_by.
+var sortableFields = orderby.Fields{
+	"name":             {},
+	"author":           {},
+	"title":            {},
+	"publication_year": {},
+	"create_time":      {},
+	"update_time":      {},
+}
+
+// tiebreakerFields identify a book, so sorting by them last makes every ordering deterministic.
+var tiebreakerFields = []string{"shelf_name", "name"}
+
 func NewListBooks(gateway ListBooksGateway) *ListBooksDomain {
 	return &ListBooksDomain{gateway: gateway}
 }
 
+// ListBooksGateway lists the books of page sorted by ordering.
 type ListBooksGateway interface {
-	ListBooks(ctx context.Context, pageSize, pageOffset int) ([]*entities.Book, error)
-	ListShelfBooks(ctx context.Context, shelfName string, pageSize, pageOffset int) ([]*entities.Book, error)
-	CountBooks(ctx context.Context) (int, error)
-	CountShelfBooks(ctx context.Context, shelfName string) (int, error)
+	ListBooks(
+		ctx context.Context,
+		expr filter.Expr,
+		showDeleted bool,
+		ordering []orderby.Field,
+		page pagination.Page,
+	) ([]*entities.Book, error)
+	ListShelfBooks(
+		ctx context.Context,
+		shelfName string,
+		expr filter.Expr,
+		showDeleted bool,
+		ordering []orderby.Field,
+		page pagination.Page,
+	) ([]*entities.Book, error)
+	CountBooks(ctx context.Context, expr filter.Expr, showDeleted bool) (int, error)
+	CountShelfBooks(ctx context.Context, shelfName string, expr filter.Expr, showDeleted bool) (int, error)
 }
 
+// List returns the books matching the AIP-160 filter expression sorted by the AIP-132 order_by.
+// Empty filter matches every book and empty order_by sorts by shelf and book name.
+// Deleted books are only listed if showDeleted is true.
+//
+// The page starts right after its cursor, which is nil for the first page, and skipped books.
+// The returned cursor points to the last book of the page and is nil when there are no more pages.
 func (l *ListBooksDomain) List(
 	ctx context.Context,
-	shelfName string,
-	pageSize, pageOffset int,
-) (books []*entities.Book, finished bool, err error) {
-	var totalBooks int
+	shelfName, filterExpression, orderBy string,
+	showDeleted bool,
+	page pagination.Page,
+) (books []*entities.Book, next pagination.Cursor, err error) {
+	expr, err := filter.Parse(filterExpression, filterableFields)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse filter: %w", err)
+	}
+
+	ordering, err := orderby.Parse(orderBy, sortableFields)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse order_by: %w", err)
+	}
+
+	ordering = orderby.WithTiebreakers(ordering, tiebreakerFields...)
+	if page.After != nil && len(page.After) != len(ordering) {
+		return nil, nil, &errors.BadRequestError{
+			InvalidField: "page_token",
+			Details:      "page_token doesn't match the request order_by",
+		}
+	}
+
+	// One more book than the page size tells whether there is a next page.
+	gatewayPage := pagination.Page{After: page.After, Skip: page.Skip, Size: page.Size + 1}
 
 	if shelfName == "-" {
-		books, totalBooks, err = l.listBooks(ctx, pageSize, pageOffset)
+		books, err = l.gateway.ListBooks(ctx, expr, showDeleted, ordering, gatewayPage)
 		if err != nil {
-			return nil, false, fmt.Errorf("failed to list books: %w", err)
+			return nil, nil, fmt.Errorf("failed to list books in gateway: %w", err)
 		}
 	} else {
-		books, totalBooks, err = l.listShelfBooks(ctx, shelfName, pageSize, pageOffset)
+		books, err = l.gateway.ListShelfBooks(ctx, shelfName, expr, showDeleted, ordering, gatewayPage)
 		if err != nil {
-			return nil, false, fmt.Errorf("failed to list shelf books: %w", err)
+			return nil, nil, fmt.Errorf("failed to list shelf books in gateway: %w", err)
 		}
 	}
 
-	finished = totalBooks <= pageOffset+pageSize
+	if len(books) <= page.Size {
+		return books, nil, nil
+	}
+
+	books = books[:page.Size]
 
-	return books, finished, nil
+	return books, bookCursor(books[len(books)-1], ordering), nil
 }
 
-func (l *ListBooksDomain) listShelfBooks(
+// Count returns the total number of books matching the AIP-160 filter expression, ignoring pagination.
+// It costs another query, so it is meant to be called only when the client asks for it.
+func (l *ListBooksDomain) Count(
 	ctx context.Context,
-	shelfName string,
-	pageSize, pageOffset int,
-) (books []*entities.Book, totalBooks int, err error) {
-	books, err = l.gateway.ListShelfBooks(ctx, shelfName, pageSize, pageOffset)
+	shelfName, filterExpression string,
+	showDeleted bool,
+) (int, error) {
+	expr, err := filter.Parse(filterExpression, filterableFields)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to list shelf books in gateway: %w", err)
+		return 0, fmt.Errorf("failed to parse filter: %w", err)
 	}
 
-	totalBooks, err = l.gateway.CountShelfBooks(ctx, shelfName)
+	if shelfName == "-" {
+		totalBooks, err := l.gateway.CountBooks(ctx, expr, showDeleted)
+		if err != nil {
+			return 0, fmt.Errorf("failed to count books in gateway: %w", err)
+		}
+
+		return totalBooks, nil
+	}
+
+	totalBooks, err := l.gateway.CountShelfBooks(ctx, shelfName, expr, showDeleted)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count shelf books in gateway: %w", err)
+		return 0, fmt.Errorf("failed to count shelf books in gateway: %w", err)
 	}
 
-	return books, totalBooks, nil
+	return totalBooks, nil
 }
 
-func (l *ListBooksDomain) listBooks(
-	ctx context.Context,
-	pageSize, pageOffset int,
-) (books []*entities.Book, totalBooks int, err error) {
-	books, err = l.gateway.ListBooks(ctx, pageSize, pageOffset)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to list shelf books in gateway: %w", err)
-	}
+// bookCursor returns the sort key of book in ordering.
+func bookCursor(book *entities.Book, ordering []orderby.Field) pagination.Cursor {
+	cursor := make(pagination.Cursor, len(ordering))
 
-	totalBooks, err = l.gateway.CountBooks(ctx)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count shelf books in gateway: %w", err)
+	for i, field := range ordering {
+		switch field.Name {
+		case "shelf_name":
+			cursor[i] = book.Shelf.Name
+		case "name":
+			cursor[i] = book.Name
+		case "author":
+			cursor[i] = book.Author()
+		case "title":
+			cursor[i] = book.Title
+		case "publication_year":
+			cursor[i] = int64(book.PublicationYear)
+		case "volume_number":
+			cursor[i] = int64(book.VolumeNumber)
+		case "create_time":
+			cursor[i] = book.CreateTime
+		case "update_time":
+			cursor[i] = book.UpdateTime
+		}
 	}
 
-	return books, totalBooks, nil
+	return cursor
 }
@@ -0,0 +1,77 @@
+package books
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/errors"
+	"github.com/Henrod/library/domain/orderby"
+	"github.com/Henrod/library/domain/pagination"
+)
+
+// parentBooks lists the not deleted books linked to a parent resource, e.g. to an author, from every shelf.
+type parentBooks struct {
+	// noun names the parent in the errors, e.g. "author".
+	noun string
+	// request names the list request in the page token error, e.g. "list author books".
+	request  string
+	ordering []orderby.Field
+}
+
+// parentFinder tells whether the parent of name exists.
+type parentFinder func(ctx context.Context, parentName string) (bool, error)
+
+// parentBooksLister lists the books of the parent of name in page sorted by ordering.
+type parentBooksLister func(
+	ctx context.Context,
+	parentName string,
+	ordering []orderby.Field,
+	page pagination.Page,
+) ([]*entities.Book, error)
+
+// list returns the books of the parent, or NotFoundError if findParent doesn't find it.
+//
+// The page starts right after its cursor, which is nil for the first page.
+// The returned cursor points to the last book of the page and is nil when there are no more pages.
+func (p parentBooks) list(
+	ctx context.Context,
+	parentName string,
+	page pagination.Page,
+	findParent parentFinder,
+	listBooks parentBooksLister,
+) (books []*entities.Book, next pagination.Cursor, err error) {
+	if page.After != nil && len(page.After) != len(p.ordering) {
+		return nil, nil, &errors.BadRequestError{
+			InvalidField: "page_token",
+			Details:      fmt.Sprintf("page_token is not from a %s request", p.request),
+		}
+	}
+
+	found, err := findParent(ctx, parentName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get %s from gateway: %w", p.noun, err)
+	}
+
+	if !found {
+		return nil, nil, errors.NotFoundError{
+			Details: fmt.Sprintf("%s %s not found", p.noun, parentName),
+		}
+	}
+
+	// One more book than the page size tells whether there is a next page.
+	gatewayPage := pagination.Page{After: page.After, Skip: page.Skip, Size: page.Size + 1}
+
+	books, err = listBooks(ctx, parentName, p.ordering, gatewayPage)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to %s in gateway: %w", p.request, err)
+	}
+
+	if len(books) <= page.Size {
+		return books, nil, nil
+	}
+
+	books = books[:page.Size]
+
+	return books, bookCursor(books[len(books)-1], p.ordering), nil
+}
@@ -0,0 +1,99 @@
+package books
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/orderby"
+	"github.com/Henrod/library/domain/pagination"
+)
+
+// seriesBooks sorts the series books by volume number, books of unknown volume first.
+// Copies of a volume in different shelves are sorted by shelf and name.
+var seriesBooks = parentBooks{
+	noun:    "series",
+	request: "list series books",
+	ordering: orderby.WithTiebreakers(
+		[]orderby.Field{{Name: "volume_number", Descending: false}},
+		tiebreakerFields...,
+	),
+}
+
+// maxMissingVolumes is the maximum number of missing volumes reported, the lowest ones.
+const maxMissingVolumes = 1000
+
+type ListSeriesBooksDomain struct {
+	gateway ListSeriesBooksGateway
+}
+
+func NewListSeriesBooksDomain(gateway ListSeriesBooksGateway) *ListSeriesBooksDomain {
+	return &ListSeriesBooksDomain{gateway: gateway}
+}
+
+// ListSeriesBooksGateway lists the not deleted books of the series, from every shelf.
+type ListSeriesBooksGateway interface {
+	GetSeries(ctx context.Context, seriesName string) (*entities.Series, error)
+	ListSeriesBooks(
+		ctx context.Context,
+		seriesName string,
+		ordering []orderby.Field,
+		page pagination.Page,
+	) ([]*entities.Book, error)
+	// SeriesVolumeNumbers returns the distinct known volume numbers of the not deleted books of the series.
+	SeriesVolumeNumbers(ctx context.Context, seriesName string) ([]int, error)
+}
+
+// List returns the books of the series across every shelf, sorted by volume number,
+// and the volumes of the series without any book, which are the same on every page.
+// Volumes are expected from 1 to the series volume count, or to the highest volume in the library if greater.
+func (l *ListSeriesBooksDomain) List(
+	ctx context.Context,
+	seriesName string,
+	page pagination.Page,
+) (books []*entities.Book, missingVolumes []int, next pagination.Cursor, err error) {
+	var series *entities.Series
+
+	findSeries := func(ctx context.Context, seriesName string) (found bool, err error) {
+		series, err = l.gateway.GetSeries(ctx, seriesName)
+
+		return series != nil, err //nolint:wrapcheck
+	}
+
+	books, next, err = seriesBooks.list(ctx, seriesName, page, findSeries, l.gateway.ListSeriesBooks)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	volumeNumbers, err := l.gateway.SeriesVolumeNumbers(ctx, seriesName)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get series volume numbers from gateway: %w", err)
+	}
+
+	return books, seriesMissingVolumes(series.VolumeCount, volumeNumbers), next, nil
+}
+
+// seriesMissingVolumes returns the volumes from 1 to the last volume not in volumeNumbers, in order.
+// The last volume is the volume count, or the highest volume number if greater.
+func seriesMissingVolumes(volumeCount int, volumeNumbers []int) []int {
+	lastVolume := volumeCount
+	present := make(map[int]struct{}, len(volumeNumbers))
+
+	for _, volumeNumber := range volumeNumbers {
+		present[volumeNumber] = struct{}{}
+
+		if volumeNumber > lastVolume {
+			lastVolume = volumeNumber
+		}
+	}
+
+	missingVolumes := make([]int, 0)
+
+	for volume := 1; volume <= lastVolume && len(missingVolumes) < maxMissingVolumes; volume++ {
+		if _, ok := present[volume]; !ok {
+			missingVolumes = append(missingVolumes, volume)
+		}
+	}
+
+	return missingVolumes
+}
@@ -0,0 +1,50 @@
+package books
+
+import (
+	"context"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/orderby"
+	"github.com/Henrod/library/domain/pagination"
+)
+
+// workEditions sorts the work editions by shelf and name, as books are listed by default.
+var workEditions = parentBooks{
+	noun:     "work",
+	request:  "list work editions",
+	ordering: orderby.WithTiebreakers(nil, tiebreakerFields...),
+}
+
+type ListWorkEditionsDomain struct {
+	gateway ListWorkEditionsGateway
+}
+
+func NewListWorkEditionsDomain(gateway ListWorkEditionsGateway) *ListWorkEditionsDomain {
+	return &ListWorkEditionsDomain{gateway: gateway}
+}
+
+// ListWorkEditionsGateway lists the not deleted books linked to the work as its editions, from every shelf.
+type ListWorkEditionsGateway interface {
+	GetWork(ctx context.Context, workName string) (*entities.Work, error)
+	ListWorkEditions(
+		ctx context.Context,
+		workName string,
+		ordering []orderby.Field,
+		page pagination.Page,
+	) ([]*entities.Book, error)
+}
+
+// List returns the editions of the work across every shelf, sorted by shelf and book name.
+func (l *ListWorkEditionsDomain) List(
+	ctx context.Context,
+	workName string,
+	page pagination.Page,
+) (books []*entities.Book, next pagination.Cursor, err error) {
+	findWork := func(ctx context.Context, workName string) (bool, error) {
+		work, err := l.gateway.GetWork(ctx, workName)
+
+		return work != nil, err //nolint:wrapcheck
+	}
+
+	return workEditions.list(ctx, workName, page, findWork, l.gateway.ListWorkEditions)
+}
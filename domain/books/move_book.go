@@ -0,0 +1,63 @@
+package books
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/errors"
+)
+
+type MoveBookDomain struct {
+	gateway MoveBookGateway
+}
+
+func NewMoveBookDomain(gateway MoveBookGateway) *MoveBookDomain {
+	return &MoveBookDomain{gateway: gateway}
+}
+
+// MoveBookGateway moves a book to another shelf.
+// If book not found, returns nil book and nil error.
+// If the destination shelf doesn't exist, returns ErrShelfNotFound,
+// and if it has a book with the same name, ErrBookExists.
+type MoveBookGateway interface {
+	MoveBook(ctx context.Context, shelfName, bookName, destinationShelfName string) (*entities.Book, error)
+}
+
+// MoveBook relocates a book from shelfName to destinationShelfName.
+// Returns NotFoundError if either the book or the destination shelf doesn't exist,
+// and AlreadyExistsError if the destination shelf has a book with the same name.
+func (m *MoveBookDomain) MoveBook(
+	ctx context.Context,
+	shelfName, bookName, destinationShelfName string,
+) (*entities.Book, error) {
+	if shelfName == destinationShelfName {
+		return nil, &errors.BadRequestError{
+			InvalidField: "destination_shelf",
+			Details:      "destination_shelf must be different from the book's current shelf",
+		}
+	}
+
+	book, err := m.gateway.MoveBook(ctx, shelfName, bookName, destinationShelfName)
+	switch {
+	case stderrors.Is(err, ErrShelfNotFound):
+		return nil, errors.NotFoundError{
+			Details: fmt.Sprintf("shelf %s not found", destinationShelfName),
+		}
+	case stderrors.Is(err, ErrBookExists):
+		return nil, errors.AlreadyExistsError{
+			Details: fmt.Sprintf("book %s at shelf %s already exists", bookName, destinationShelfName),
+		}
+	case err != nil:
+		return nil, fmt.Errorf("failed to move book in gateway: %w", err)
+	}
+
+	if book == nil {
+		return nil, errors.NotFoundError{
+			Details: fmt.Sprintf("book %s at shelf %s not found", bookName, shelfName),
+		}
+	}
+
+	return book, nil
+}
@@ -0,0 +1,48 @@
+package books
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type PurgeBooksDomain struct {
+	gateway  PurgeBooksGateway
+	interval time.Duration
+	log      *zap.SugaredLogger
+}
+
+// NewPurgeBooksDomain returns the PurgeBooksDomain, which looks for books to purge every interval.
+func NewPurgeBooksDomain(log *zap.SugaredLogger, gateway PurgeBooksGateway, interval time.Duration) *PurgeBooksDomain {
+	return &PurgeBooksDomain{gateway: gateway, interval: interval, log: log}
+}
+
+type PurgeBooksGateway interface {
+	PurgeBooks(ctx context.Context, now time.Time) (int, error)
+}
+
+// Run permanently removes the deleted books after their purge time until ctx is done.
+// Failures are logged and retried in the next interval.
+func (p *PurgeBooksDomain) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			purged, err := p.gateway.PurgeBooks(ctx, now)
+			if err != nil {
+				p.log.With(zap.Error(err)).Error("failed to purge books in gateway")
+
+				continue
+			}
+
+			if purged > 0 {
+				p.log.Infof("purged %d deleted books", purged)
+			}
+		}
+	}
+}
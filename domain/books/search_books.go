@@ -0,0 +1,206 @@
+package books
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/errors"
+	"github.com/Henrod/library/domain/filter"
+	"github.com/Henrod/library/domain/orderby"
+	"github.com/Henrod/library/domain/pagination"
+)
+
+const (
+	// maxSearchQueryLength is the maximum number of characters of a search query.
+	maxSearchQueryLength = 1000
+	// maxFacetBuckets is the number of most common values counted per facet.
+	maxFacetBuckets = 10
+)
+
+// searchOrdering sorts the most relevant books first, and books with the same relevance by name,
+// so pages are deterministic.
+var searchOrdering = []orderby.Field{
+	{Name: "relevance", Descending: true},
+	{Name: "shelf_name", Descending: false},
+	{Name: "name", Descending: false},
+}
+
+// facetFields are the book fields the search results can be counted by.
+var facetFields = map[string]struct{}{
+	"author":           {},
+	"shelf":            {},
+	"language":         {},
+	"publication_year": {},
+}
+
+type SearchBooksDomain struct {
+	gateway SearchBooksGateway
+}
+
+func NewSearchBooksDomain(gateway SearchBooksGateway) *SearchBooksDomain {
+	return &SearchBooksDomain{gateway: gateway}
+}
+
+// SearchBooksGateway searches the books matching a full-text query and filter expression, ignoring deleted books.
+// Ordering fields are relevance, shelf_name and name.
+// Facets count the matching books per value of field, returning the limit most common values.
+type SearchBooksGateway interface {
+	SearchBooks(
+		ctx context.Context,
+		query string,
+		expr filter.Expr,
+		ordering []orderby.Field,
+		page pagination.Page,
+	) ([]*entities.BookSearchResult, error)
+	SearchShelfBooks(
+		ctx context.Context,
+		shelfName, query string,
+		expr filter.Expr,
+		ordering []orderby.Field,
+		page pagination.Page,
+	) ([]*entities.BookSearchResult, error)
+	SearchBooksFacet(
+		ctx context.Context,
+		query string,
+		expr filter.Expr,
+		field string,
+		limit int,
+	) ([]*entities.FacetBucket, error)
+	SearchShelfBooksFacet(
+		ctx context.Context,
+		shelfName, query string,
+		expr filter.Expr,
+		field string,
+		limit int,
+	) ([]*entities.FacetBucket, error)
+}
+
+// Search returns the books of shelf matching query and the AIP-160 filter, from the most to the least relevant.
+// Shelf "-" searches books from every shelf.
+//
+// The page starts right after its cursor, which is nil for the first page.
+// The returned cursor points to the last result of the page and is nil when there are no more pages.
+func (s *SearchBooksDomain) Search(
+	ctx context.Context,
+	shelfName, query, filterExpression string,
+	page pagination.Page,
+) (results []*entities.BookSearchResult, next pagination.Cursor, err error) {
+	expr, err := parseSearch(query, filterExpression)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if page.After != nil && len(page.After) != len(searchOrdering) {
+		return nil, nil, &errors.BadRequestError{
+			InvalidField: "page_token",
+			Details:      "page_token is not from a search request",
+		}
+	}
+
+	// One more result than the page size tells whether there is a next page.
+	gatewayPage := pagination.Page{After: page.After, Skip: page.Skip, Size: page.Size + 1}
+
+	if shelfName == "-" {
+		results, err = s.gateway.SearchBooks(ctx, query, expr, searchOrdering, gatewayPage)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to search books in gateway: %w", err)
+		}
+	} else {
+		results, err = s.gateway.SearchShelfBooks(ctx, shelfName, query, expr, searchOrdering, gatewayPage)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to search shelf books in gateway: %w", err)
+		}
+	}
+
+	if len(results) <= page.Size {
+		return results, nil, nil
+	}
+
+	results = results[:page.Size]
+	last := results[len(results)-1]
+
+	return results, pagination.Cursor{last.Relevance, last.Book.Shelf.Name, last.Book.Name}, nil
+}
+
+// Facets counts the books of shelf matching query and the AIP-160 filter per value of each field,
+// ignoring pagination. The facets are in the same order as fields, each with its most common values.
+// It costs a query per field, so it is meant to be called only when the client asks for it.
+func (s *SearchBooksDomain) Facets(
+	ctx context.Context,
+	shelfName, query, filterExpression string,
+	fields []string,
+) ([]*entities.Facet, error) {
+	expr, err := parseSearch(query, filterExpression)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(fields))
+
+	for i, field := range fields {
+		if _, ok := facetFields[field]; !ok {
+			return nil, &errors.BadRequestError{
+				InvalidField: fmt.Sprintf("facets[%d]", i),
+				Details:      fmt.Sprintf("field %q is not a facet, must be author, shelf, language or publication_year", field),
+			}
+		}
+
+		if _, ok := seen[field]; ok {
+			return nil, &errors.BadRequestError{
+				InvalidField: fmt.Sprintf("facets[%d]", i),
+				Details:      fmt.Sprintf("facet %q is repeated", field),
+			}
+		}
+
+		seen[field] = struct{}{}
+	}
+
+	facets := make([]*entities.Facet, len(fields))
+
+	for i, field := range fields {
+		var buckets []*entities.FacetBucket
+
+		if shelfName == "-" {
+			buckets, err = s.gateway.SearchBooksFacet(ctx, query, expr, field, maxFacetBuckets)
+			if err != nil {
+				return nil, fmt.Errorf("failed to count books facet in gateway: %w", err)
+			}
+		} else {
+			buckets, err = s.gateway.SearchShelfBooksFacet(ctx, shelfName, query, expr, field, maxFacetBuckets)
+			if err != nil {
+				return nil, fmt.Errorf("failed to count shelf books facet in gateway: %w", err)
+			}
+		}
+
+		facets[i] = &entities.Facet{Field: field, Buckets: buckets}
+	}
+
+	return facets, nil
+}
+
+// parseSearch validates the search query and returns the parsed filter expression.
+func parseSearch(query, filterExpression string) (filter.Expr, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, &errors.BadRequestError{
+			InvalidField: "query",
+			Details:      "query must have words to search",
+		}
+	}
+
+	if utf8.RuneCountInString(query) > maxSearchQueryLength {
+		return nil, &errors.BadRequestError{
+			InvalidField: "query",
+			Details:      fmt.Sprintf("query must have at most %d characters", maxSearchQueryLength),
+		}
+	}
+
+	expr, err := filter.Parse(filterExpression, filterableFields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse filter: %w", err)
+	}
+
+	return expr, nil
+}
@@ -0,0 +1,99 @@
+package books
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/errors"
+)
+
+const (
+	defaultSuggestions = 5
+	maxSuggestions     = 20
+	// maxSuggestionPrefixLength is the maximum number of characters of the typed prefix.
+	maxSuggestionPrefixLength = 100
+	// suggestionCandidatesFactor is how many more candidates than suggestions are fetched,
+	// so the best suggestions are still there after they are ranked.
+	suggestionCandidatesFactor = 4
+)
+
+type SuggestBooksDomain struct {
+	gateway SuggestBooksGateway
+}
+
+func NewSuggestBooksDomain(gateway SuggestBooksGateway) *SuggestBooksDomain {
+	return &SuggestBooksDomain{gateway: gateway}
+}
+
+// SuggestBooksGateway returns at most limit titles and authors that may complete prefix, ignoring deleted books.
+// Candidates must include the texts with a word whose beginning has at least minScore trigram similarity to prefix.
+// The candidates are ranked by the domain, so a gateway without similarity search may return any of them,
+// e.g. every title and author.
+type SuggestBooksGateway interface {
+	SuggestBooks(ctx context.Context, prefix string, minScore float64, limit int) ([]*entities.BookSuggestion, error)
+	SuggestShelfBooks(
+		ctx context.Context,
+		shelfName, prefix string,
+		minScore float64,
+		limit int,
+	) ([]*entities.BookSuggestion, error)
+}
+
+// Suggest returns the titles and authors of shelf books most similar to prefix, tolerating typos.
+// Shelf "-" suggests from every shelf.
+// Zero maxCount returns the default number of suggestions and maxCount is coerced to the maximum if bigger.
+func (s *SuggestBooksDomain) Suggest(
+	ctx context.Context,
+	shelfName, prefix string,
+	maxCount int,
+) ([]*entities.BookSuggestion, error) {
+	if strings.TrimSpace(prefix) == "" {
+		return nil, &errors.BadRequestError{
+			InvalidField: "prefix",
+			Details:      "prefix must not be empty",
+		}
+	}
+
+	if utf8.RuneCountInString(prefix) > maxSuggestionPrefixLength {
+		return nil, &errors.BadRequestError{
+			InvalidField: "prefix",
+			Details:      fmt.Sprintf("prefix must have at most %d characters", maxSuggestionPrefixLength),
+		}
+	}
+
+	switch {
+	case maxCount < 0:
+		return nil, &errors.BadRequestError{
+			InvalidField: "max_suggestions",
+			Details:      "max_suggestions must not be negative",
+		}
+	case maxCount == 0:
+		maxCount = defaultSuggestions
+	case maxCount > maxSuggestions:
+		maxCount = maxSuggestions
+	}
+
+	var (
+		candidates []*entities.BookSuggestion
+		err        error
+	)
+
+	if shelfName == "-" {
+		candidates, err = s.gateway.SuggestBooks(ctx, prefix, minSuggestionScore, maxCount*suggestionCandidatesFactor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to suggest books in gateway: %w", err)
+		}
+	} else {
+		candidates, err = s.gateway.SuggestShelfBooks(
+			ctx, shelfName, prefix, minSuggestionScore, maxCount*suggestionCandidatesFactor,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to suggest shelf books in gateway: %w", err)
+		}
+	}
+
+	return rankSuggestions(prefix, candidates, maxCount), nil
+}
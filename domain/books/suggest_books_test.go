@@ -0,0 +1,196 @@
+package books
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/errors"
+)
+
+// memorySuggestBooksGateway suggests every title and author of its books, without similarity search.
+type memorySuggestBooksGateway struct {
+	books []*entities.Book
+}
+
+func (m *memorySuggestBooksGateway) SuggestBooks(
+	ctx context.Context,
+	prefix string,
+	minScore float64,
+	limit int,
+) ([]*entities.BookSuggestion, error) {
+	return m.SuggestShelfBooks(ctx, "", prefix, minScore, limit)
+}
+
+// SuggestShelfBooks returns the candidates of shelfName, or of every shelf if it is empty.
+func (m *memorySuggestBooksGateway) SuggestShelfBooks(
+	_ context.Context,
+	shelfName, _ string,
+	_ float64,
+	limit int,
+) ([]*entities.BookSuggestion, error) {
+	var candidates []*entities.BookSuggestion
+
+	for _, book := range m.books {
+		if !book.DeleteTime.IsZero() || (shelfName != "" && book.Shelf.Name != shelfName) {
+			continue
+		}
+
+		candidates = append(candidates,
+			&entities.BookSuggestion{Text: book.Title, Field: entities.SuggestionFieldTitle, Score: 0},
+			&entities.BookSuggestion{Text: book.Author(), Field: entities.SuggestionFieldAuthor, Score: 0},
+		)
+	}
+
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	return candidates, nil
+}
+
+func suggestionsString(suggestions []*entities.BookSuggestion) string {
+	texts := make([]string, len(suggestions))
+	for i, suggestion := range suggestions {
+		texts[i] = fmt.Sprintf("%s %s %.2f", suggestion.Field, suggestion.Text, suggestion.Score)
+	}
+
+	return "[" + strings.Join(texts, ", ") + "]"
+}
+
+func TestSuggest(t *testing.T) {
+	t.Parallel()
+
+	book := func(shelfName, title, author string, deleted bool) *entities.Book {
+		book := &entities.Book{ //nolint:exhaustivestruct
+			Title:        title,
+			Contributors: []*entities.Contributor{{Name: author, Role: entities.ContributorRoleAuthor}},
+			Shelf:        &entities.Shelf{Name: shelfName}, //nolint:exhaustivestruct
+		}
+
+		if deleted {
+			book.DeleteTime = time.Now()
+		}
+
+		return book
+	}
+
+	gateway := &memorySuggestBooksGateway{books: []*entities.Book{
+		book("shelf1", "Dune", "Frank Herbert", false),
+		book("shelf1", "Dune Messiah", "Frank Herbert", false),
+		book("shelf2", "Children of Dune", "Frank Herbert", false),
+		book("shelf2", "Dune Encyclopedia", "Willis McNelly", true),
+		book("shelf2", "Foundation", "Isaac Asimov", false),
+	}}
+
+	suggestion := func(field entities.SuggestionField, text string, score float64) *entities.BookSuggestion {
+		return &entities.BookSuggestion{Text: text, Field: field, Score: score}
+	}
+
+	tests := []struct {
+		name      string
+		shelfName string
+		prefix    string
+		maxCount  int
+		want      []*entities.BookSuggestion
+	}{
+		{
+			name:      "every shelf",
+			shelfName: "-",
+			prefix:    "dune",
+			maxCount:  0,
+			want: []*entities.BookSuggestion{
+				suggestion(entities.SuggestionFieldTitle, "Dune", 1),
+				suggestion(entities.SuggestionFieldTitle, "Dune Messiah", 1),
+				suggestion(entities.SuggestionFieldTitle, "Children of Dune", 1),
+			},
+		},
+		{
+			name:      "shelf",
+			shelfName: "shelf2",
+			prefix:    "dune",
+			maxCount:  0,
+			want: []*entities.BookSuggestion{
+				suggestion(entities.SuggestionFieldTitle, "Children of Dune", 1),
+			},
+		},
+		{
+			name:      "typo in author",
+			shelfName: "-",
+			prefix:    "herbet",
+			maxCount:  0,
+			// "herbet" and "herber" share "  h", " he", "her", "erb" and "rbe" of 7 and 7 trigrams.
+			want: []*entities.BookSuggestion{
+				suggestion(entities.SuggestionFieldAuthor, "Frank Herbert", 5.0/9),
+			},
+		},
+		{
+			name:      "max count",
+			shelfName: "-",
+			prefix:    "dune",
+			maxCount:  1,
+			want: []*entities.BookSuggestion{
+				suggestion(entities.SuggestionFieldTitle, "Dune", 1),
+			},
+		},
+		{
+			name:      "no suggestion",
+			shelfName: "-",
+			prefix:    "zzz",
+			maxCount:  0,
+			want:      []*entities.BookSuggestion{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewSuggestBooksDomain(gateway).Suggest(context.Background(), tt.shelfName, tt.prefix, tt.maxCount)
+			if err != nil {
+				t.Fatalf("Suggest(%q) returned error: %v", tt.prefix, err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Suggest(%q) = %s, want %s", tt.prefix, suggestionsString(got), suggestionsString(tt.want))
+			}
+		})
+	}
+}
+
+func TestSuggestErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		prefix       string
+		maxCount     int
+		invalidField string
+	}{
+		{name: "empty prefix", prefix: " ", maxCount: 0, invalidField: "prefix"},
+		{name: "long prefix", prefix: strings.Repeat("a", maxSuggestionPrefixLength+1), maxCount: 0, invalidField: "prefix"},
+		{name: "negative max count", prefix: "dune", maxCount: -1, invalidField: "max_suggestions"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			domain := NewSuggestBooksDomain(&memorySuggestBooksGateway{books: nil})
+			_, err := domain.Suggest(context.Background(), "-", tt.prefix, tt.maxCount)
+
+			var badRequest *errors.BadRequestError
+			if !stderrors.As(err, &badRequest) || badRequest.InvalidField != tt.invalidField {
+				t.Errorf("Suggest(%q, %d) returned %v, want a BadRequestError on %s",
+					tt.prefix, tt.maxCount, err, tt.invalidField)
+			}
+		})
+	}
+}
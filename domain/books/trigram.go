@@ -0,0 +1,127 @@
+package books
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/Henrod/library/domain/entities"
+)
+
+// minSuggestionScore is the minimum similarity of a suggestion, the default similarity threshold of pg_trgm.
+// It is also the threshold of the gateway candidates, so they are filtered the same way.
+const minSuggestionScore = 0.3
+
+// rankSuggestions scores the candidates by their similarity to prefix, returning at most limit of them
+// from the most to the least similar. Candidates less similar than minSuggestionScore and repeated ones are dropped.
+//
+// It needs no database, so suggestions work the same with any gateway.
+func rankSuggestions(prefix string, candidates []*entities.BookSuggestion, limit int) []*entities.BookSuggestion {
+	seen := make(map[entities.BookSuggestion]struct{}, len(candidates))
+	suggestions := make([]*entities.BookSuggestion, 0, len(candidates))
+
+	for _, candidate := range candidates {
+		key := entities.BookSuggestion{Text: candidate.Text, Field: candidate.Field, Score: 0}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		seen[key] = struct{}{}
+
+		score := prefixSimilarity(prefix, candidate.Text)
+		if score < minSuggestionScore {
+			continue
+		}
+
+		suggestions = append(suggestions, &entities.BookSuggestion{
+			Text:  candidate.Text,
+			Field: candidate.Field,
+			Score: score,
+		})
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+
+		// Shorter texts are closer to what was typed.
+		if len(suggestions[i].Text) != len(suggestions[j].Text) {
+			return len(suggestions[i].Text) < len(suggestions[j].Text)
+		}
+
+		return suggestions[i].Text < suggestions[j].Text
+	})
+
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+
+	return suggestions
+}
+
+// prefixSimilarity is the greatest trigram similarity between prefix and the beginning of any word of text,
+// cut to the prefix length. So it is 1 when a word of text starts with prefix, and tolerates typos otherwise.
+func prefixSimilarity(prefix, text string) float64 {
+	normalizedPrefix := []rune(normalizeWords(prefix))
+	normalizedText := []rune(normalizeWords(text))
+
+	best := 0.0
+
+	for start := range normalizedText {
+		if start > 0 && normalizedText[start-1] != ' ' {
+			continue
+		}
+
+		end := start + len(normalizedPrefix)
+		if end > len(normalizedText) {
+			end = len(normalizedText)
+		}
+
+		if similarity := trigramSimilarity(string(normalizedPrefix), string(normalizedText[start:end])); similarity > best {
+			best = similarity
+		}
+	}
+
+	return best
+}
+
+// normalizeWords lowercases s and keeps only its words, separated by a single space.
+func normalizeWords(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// trigramSimilarity is the number of shared trigrams over the number of distinct trigrams of a and b,
+// which is the similarity function of pg_trgm.
+func trigramSimilarity(a, b string) float64 {
+	trigramsA, trigramsB := trigrams(a), trigrams(b)
+	if len(trigramsA) == 0 || len(trigramsB) == 0 {
+		return 0
+	}
+
+	shared := 0
+
+	for trigram := range trigramsA {
+		if _, ok := trigramsB[trigram]; ok {
+			shared++
+		}
+	}
+
+	return float64(shared) / float64(len(trigramsA)+len(trigramsB)-shared)
+}
+
+// trigrams returns the trigrams of each word of s, padded with two spaces before and one after as in pg_trgm.
+func trigrams(s string) map[string]struct{} {
+	result := make(map[string]struct{})
+
+	for _, word := range strings.Fields(s) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			result[string(padded[i:i+3])] = struct{}{}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,156 @@
+package books
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/Henrod/library/domain/entities"
+)
+
+func TestTrigramSimilarity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		a, b string
+		want float64
+	}{
+		{name: "same word", a: "dune", b: "dune", want: 1},
+		{name: "no shared trigram", a: "abc", b: "xyz", want: 0},
+		{name: "empty", a: "", b: "dune", want: 0},
+		// "dune" has 5 trigrams, "dunes" has 6 and they share "  d", " du", "dun" and "une".
+		{name: "suffix", a: "dune", b: "dunes", want: 4.0 / 7},
+		// Repeated trigrams count once: "aaa" and "aaaa" have "  a", " aa", "aaa" and "aa ".
+		{name: "repeated trigrams", a: "aaa", b: "aaaa", want: 1},
+		{name: "words are padded apart", a: "ab cd", b: "cd ab", want: 1},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := trigramSimilarity(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("trigramSimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrefixSimilarity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		prefix, text string
+		want         float64
+	}{
+		{name: "first word starts with prefix", prefix: "dun", text: "Dune Messiah", want: 1},
+		{name: "other word starts with prefix", prefix: "mess", text: "Dune Messiah", want: 1},
+		{name: "case and punctuation are ignored", prefix: "CHILDREN, of", text: "Children of Dune", want: 1},
+		// "mesiah" and "messia" share "  m", " me", "mes" and "sia" of their 7 trigrams each.
+		{name: "typo", prefix: "mesiah", text: "Dune Messiah", want: 4.0 / 10},
+		// Only the beginning of words is compared: "essiah" and "messia" share "ess", "ssi" and "sia".
+		{name: "middle of word", prefix: "essiah", text: "Dune Messiah", want: 3.0 / 11},
+		// The 5 trigrams of "dune" are all in the 13 trigrams of "dune messiah".
+		{name: "prefix longer than text", prefix: "dune messiah", text: "Dune", want: 5.0 / 13},
+		{name: "empty text", prefix: "dune", text: "", want: 0},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := prefixSimilarity(tt.prefix, tt.text); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("prefixSimilarity(%q, %q) = %v, want %v", tt.prefix, tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRankSuggestions(t *testing.T) {
+	t.Parallel()
+
+	title := func(text string) *entities.BookSuggestion {
+		return &entities.BookSuggestion{Text: text, Field: entities.SuggestionFieldTitle, Score: 0}
+	}
+
+	author := func(text string) *entities.BookSuggestion {
+		return &entities.BookSuggestion{Text: text, Field: entities.SuggestionFieldAuthor, Score: 0}
+	}
+
+	scored := func(suggestion *entities.BookSuggestion, score float64) *entities.BookSuggestion {
+		suggestion.Score = score
+
+		return suggestion
+	}
+
+	tests := []struct {
+		name       string
+		prefix     string
+		candidates []*entities.BookSuggestion
+		limit      int
+		want       []*entities.BookSuggestion
+	}{
+		{
+			name:       "no candidates",
+			prefix:     "dune",
+			candidates: nil,
+			limit:      5,
+			want:       []*entities.BookSuggestion{},
+		},
+		{
+			name:       "most similar first",
+			prefix:     "mesiah",
+			candidates: []*entities.BookSuggestion{title("Dune Messiah"), title("Mesiah")},
+			limit:      5,
+			want:       []*entities.BookSuggestion{scored(title("Mesiah"), 1), scored(title("Dune Messiah"), 0.4)},
+		},
+		{
+			name:       "shorter and then alphabetical on ties",
+			prefix:     "dune",
+			candidates: []*entities.BookSuggestion{title("Dune Messiah"), title("Dune"), title("Dune Chronicles")},
+			limit:      5,
+			want: []*entities.BookSuggestion{
+				scored(title("Dune"), 1),
+				scored(title("Dune Messiah"), 1),
+				scored(title("Dune Chronicles"), 1),
+			},
+		},
+		{
+			name:       "less similar than minimum score are dropped",
+			prefix:     "dune",
+			candidates: []*entities.BookSuggestion{title("Foundation"), author("Frank Herbert")},
+			limit:      5,
+			want:       []*entities.BookSuggestion{},
+		},
+		{
+			name:       "repeated candidates are dropped, the same text of another field is kept",
+			prefix:     "her",
+			candidates: []*entities.BookSuggestion{author("Herbert"), author("Herbert"), title("Herbert")},
+			limit:      5,
+			want:       []*entities.BookSuggestion{scored(author("Herbert"), 1), scored(title("Herbert"), 1)},
+		},
+		{
+			name:       "at most limit suggestions",
+			prefix:     "dune",
+			candidates: []*entities.BookSuggestion{title("Dune Messiah"), title("Dune")},
+			limit:      1,
+			want:       []*entities.BookSuggestion{scored(title("Dune"), 1)},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := rankSuggestions(tt.prefix, tt.candidates, tt.limit)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rankSuggestions(%q) = %s, want %s", tt.prefix, suggestionsString(got), suggestionsString(tt.want))
+			}
+		})
+	}
+}
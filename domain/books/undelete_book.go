@@ -0,0 +1,50 @@
+package books
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/errors"
+)
+
+type UndeleteBookDomain struct {
+	gateway UndeleteBookGateway
+}
+
+func NewUndeleteBookDomain(gateway UndeleteBookGateway) *UndeleteBookDomain {
+	return &UndeleteBookDomain{gateway: gateway}
+}
+
+type UndeleteBookGateway interface {
+	GetBookGateway
+	UndeleteBook(ctx context.Context, shelfName, bookName string) (*entities.Book, error)
+}
+
+// UndeleteBook restores a deleted book that was not purged yet.
+// Returns AlreadyExistsError if the book is not deleted and NotFoundError if it doesn't exist.
+func (u *UndeleteBookDomain) UndeleteBook(ctx context.Context, shelfName, bookName string) (*entities.Book, error) {
+	book, err := u.gateway.UndeleteBook(ctx, shelfName, bookName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to undelete book in gateway: %w", err)
+	}
+
+	if book != nil {
+		return book, nil
+	}
+
+	activeBook, err := u.gateway.GetBook(ctx, shelfName, bookName, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get book from gateway: %w", err)
+	}
+
+	if activeBook != nil {
+		return nil, errors.AlreadyExistsError{
+			Details: fmt.Sprintf("book %s at shelf %s is not deleted", bookName, shelfName),
+		}
+	}
+
+	return nil, errors.NotFoundError{
+		Details: fmt.Sprintf("book %s at shelf %s not found", bookName, shelfName),
+	}
+}
@@ -5,68 +5,195 @@ import (
 	"fmt"
 
 	"github.com/Henrod/library/domain/errors"
+	"github.com/Henrod/library/domain/fieldmask"
 
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/resourcename"
 )
 
 type UpdateBookDomain struct {
 	gateway UpdateBookGateway
 }
 
-var notUserUpdatableFields = map[string]struct{}{
-	"create_time": {},
-	"update_time": {},
+// outputOnlyFields are skipped in the update mask, as they are set by the server.
+var outputOnlyFields = []string{"create_time", "update_time", "delete_time", "purge_time", "etag"}
+
+// replacedFields are the user settable fields, which are all updated by the "*" update mask.
+var replacedFields = []string{
+	"contributors",
+	"author_name",
+	"series",
+	"volume_number",
+	"work",
+	"title",
+	"isbn",
+	"publisher",
+	"publication_year",
+	"language",
+	"page_count",
+	"labels",
+	"description",
 }
 
+const (
+	// fullReplacementPath is the update mask path that replaces the whole book.
+	fullReplacementPath = "*"
+	// legacyAuthorPath updates only the first author contributor, as the author of books before contributors.
+	legacyAuthorPath = "author"
+)
+
 func NewUpdateBookDomain(gateway UpdateBookGateway) *UpdateBookDomain {
 	return &UpdateBookDomain{gateway: gateway}
 }
 
+// UpdateBookGateway updates the fields of the book, if it is not deleted and has the version of book, if any.
+// The "author" field replaces the first author of the current book contributors by the book author,
+// in the same transaction as the update, so concurrent contributors changes are not lost.
+// If the book is not updated, returns nil book and nil error.
+// If the book author, series or work doesn't exist, returns ErrAuthorNotFound, ErrSeriesNotFound
+// or ErrWorkNotFound.
 type UpdateBookGateway interface {
+	GetBookGateway
+	CreateBookGateway
 	UpdateBook(ctx context.Context, shelfName string, book *entities.Book, fields []string) (*entities.Book, error)
 }
 
+// UpdateBook updates the fields of update mask, or every user settable field if the mask is "*".
+// If inputBook has a version, it must be the current book version, otherwise returns AbortedError.
+// If the book doesn't exist and allowMissing is true, it is created from inputBook, unless it has a version.
 func (g *UpdateBookDomain) UpdateBook(
 	ctx context.Context,
 	shelfName string,
 	inputBook *entities.Book,
 	updateMask *fieldmaskpb.FieldMask,
+	allowMissing bool,
 ) (*entities.Book, error) {
-	if updateMask == nil {
-		return nil, &errors.BadRequestError{
-			InvalidField: "update_mask",
-			Details:      "update_mask must contain book fields (<link to swagger book resource>)",
+	fields, err := updateFields(updateMask)
+	if err != nil {
+		return nil, err
+	}
+
+	validBook, err := validateBookFields("book.", inputBook, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	book, err := g.gateway.UpdateBook(ctx, shelfName, validBook, fields)
+	if notFound := referenceNotFound(err, shelfName, validBook); notFound != nil {
+		return nil, notFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to update book in gateway: %w", err)
+	}
+
+	if book == nil && allowMissing && inputBook.Version == 0 {
+		return g.createMissingBook(ctx, shelfName, inputBook)
+	}
+
+	if book == nil {
+		return nil, bookNotChangedError(ctx, g.gateway, shelfName, inputBook.Name, inputBook.Version)
+	}
+
+	return book, nil
+}
+
+// bookNotChangedError tells why the book was not changed by a conditional change:
+// AbortedError if it exists with a version different from the expected one, or NotFoundError otherwise.
+func bookNotChangedError(
+	ctx context.Context,
+	gateway GetBookGateway,
+	shelfName, bookName string,
+	version int64,
+) error {
+	if version != 0 {
+		book, err := gateway.GetBook(ctx, shelfName, bookName, false)
+		if err != nil {
+			return fmt.Errorf("failed to get book from gateway: %w", err)
+		}
+
+		if book != nil {
+			return errors.AbortedError{
+				Reason:  "ETAG_MISMATCH",
+				Details: fmt.Sprintf("book %s at shelf %s was changed since etag was read", bookName, shelfName),
+			}
 		}
 	}
 
-	updateMask.Normalize()
+	return errors.NotFoundError{
+		Details: fmt.Sprintf("book %s at shelf %s not found", bookName, shelfName),
+	}
+}
 
-	fields := make([]string, 0)
+// updateFields returns the book fields to update from the update mask.
+// The "*" path replaces every user settable field, so it can't be combined with other paths.
+// Other paths must be user settable fields, the legacy author or output only fields, which are skipped,
+// and the legacy author can't be combined with the contributors.
+func updateFields(updateMask *fieldmaskpb.FieldMask) ([]string, error) {
 	for _, path := range updateMask.GetPaths() {
-		if _, ok := notUserUpdatableFields[path]; ok {
+		if path != fullReplacementPath {
 			continue
 		}
 
-		fields = append(fields, path)
+		if len(updateMask.GetPaths()) > 1 {
+			return nil, &errors.BadRequestError{
+				InvalidField: "update_mask",
+				Details:      `update_mask with "*" must not have other paths`,
+			}
+		}
+
+		return replacedFields, nil
 	}
 
-	if len(fields) == 0 {
+	updatableFields := append(append(make([]string, 0, len(replacedFields)+1), replacedFields...), legacyAuthorPath)
+
+	fields, err := fieldmask.Fields(updateMask, "book", updatableFields, outputOnlyFields...)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	// The legacy author is the first author of the contributors, so updating both is ambiguous.
+	if fieldmask.Contains(fields, legacyAuthorPath) && fieldmask.Contains(fields, "contributors") {
 		return nil, &errors.BadRequestError{
 			InvalidField: "update_mask",
-			Details:      "update_mask doesn't have any valid fields to update (<link to swagger book resource>)",
+			Details:      "update_mask must not have both author and contributors paths",
 		}
 	}
 
-	book, err := g.gateway.UpdateBook(ctx, shelfName, inputBook, fields)
+	return fields, nil
+}
+
+// createMissingBook creates the book not found by an update with allow_missing.
+// If the book is deleted, it isn't missing, so it must be undeleted before the update.
+func (g *UpdateBookDomain) createMissingBook(
+	ctx context.Context,
+	shelfName string,
+	inputBook *entities.Book,
+) (*entities.Book, error) {
+	if err := resourcename.ValidateID("book.name", inputBook.Name); err != nil {
+		return nil, fmt.Errorf("invalid book id: %w", err)
+	}
+
+	// The book is created with every input field, not only the updated ones.
+	newBook, err := validateBookFields("book.", inputBook, replacedFields)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update book in gateway: %w", err)
+		return nil, err
+	}
+
+	book, err := g.gateway.CreateBook(ctx, shelfName, newBook)
+	if notFound := referenceNotFound(err, shelfName, newBook); notFound != nil {
+		return nil, notFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create missing book in gateway: %w", err)
 	}
 
 	if book == nil {
-		return nil, errors.NotFoundError{
-			Details: fmt.Sprintf("book %s at shelf %s not found", shelfName, inputBook.Name),
+		return nil, errors.AlreadyExistsError{
+			Details: fmt.Sprintf("book %s at shelf %s already exists, it may be deleted", inputBook.Name, shelfName),
 		}
 	}
 
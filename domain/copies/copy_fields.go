@@ -0,0 +1,124 @@
+package copies
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/errors"
+	"github.com/Henrod/library/domain/filter"
+	"github.com/Henrod/library/domain/orderby"
+	"github.com/Henrod/library/domain/resources"
+)
+
+// maxBarcodeLength is the maximum number of characters of a copy barcode.
+const maxBarcodeLength = 64
+
+// barcodeRegexp matches the characters encoded by the library scanners.
+var barcodeRegexp = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+
+// copyKind describes the copies to the use cases shared by the resources.
+var copyKind = resources.Kind{
+	Noun: "copy",
+	Filterable: filter.Fields{
+		"name":    filter.String,
+		"barcode": filter.String,
+		"condition": filter.Enum(
+			string(entities.CopyConditionNew),
+			string(entities.CopyConditionGood),
+			string(entities.CopyConditionFair),
+			string(entities.CopyConditionPoor),
+			string(entities.CopyConditionDamaged),
+		),
+		"status": filter.Enum(
+			string(entities.CopyStatusAvailable),
+			string(entities.CopyStatusOnLoan),
+			string(entities.CopyStatusInRepair),
+			string(entities.CopyStatusLost),
+		),
+		"create_time": filter.Timestamp,
+		"update_time": filter.Timestamp,
+	},
+	Sortable: orderby.Fields{
+		"name":        {},
+		"barcode":     {},
+		"create_time": {},
+		"update_time": {},
+	},
+	Settable: []string{"barcode", "acquisition_date", "condition", "status"},
+}
+
+// copyConditions are the valid conditions of a copy.
+var copyConditions = map[entities.CopyCondition]struct{}{
+	entities.CopyConditionNew:     {},
+	entities.CopyConditionGood:    {},
+	entities.CopyConditionFair:    {},
+	entities.CopyConditionPoor:    {},
+	entities.CopyConditionDamaged: {},
+}
+
+// copyStatuses are the valid statuses of a copy.
+var copyStatuses = map[entities.CopyStatus]struct{}{
+	entities.CopyStatusAvailable: {},
+	entities.CopyStatusOnLoan:    {},
+	entities.CopyStatusInRepair:  {},
+	entities.CopyStatusLost:      {},
+}
+
+// validateCopyFields validates the fields of bookCopy.
+func validateCopyFields(bookCopy *entities.Copy, fields []string) error {
+	for _, field := range fields {
+		var err error
+
+		switch field {
+		case "barcode":
+			err = validateBarcode("copy.barcode", bookCopy.Barcode)
+		case "acquisition_date":
+			if bookCopy.AcquisitionDate.After(time.Now()) {
+				err = &errors.BadRequestError{
+					InvalidField: "copy.acquisition_date",
+					Details:      "copy.acquisition_date must not be in the future",
+				}
+			}
+		case "condition":
+			if _, ok := copyConditions[bookCopy.Condition]; !ok {
+				err = &errors.BadRequestError{
+					InvalidField: "copy.condition",
+					Details:      "copy.condition must be a valid condition",
+				}
+			}
+		case "status":
+			if _, ok := copyStatuses[bookCopy.Status]; !ok {
+				err = &errors.BadRequestError{
+					InvalidField: "copy.status",
+					Details:      "copy.status must be a valid status",
+				}
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// copyPath describes the copy of a book in the errors.
+func copyPath(shelfName, bookName, copyName string) string {
+	return fmt.Sprintf("%s of book %s at shelf %s", copyName, bookName, shelfName)
+}
+
+func validateBarcode(field, barcode string) error {
+	if len(barcode) > maxBarcodeLength || !barcodeRegexp.MatchString(barcode) {
+		return &errors.BadRequestError{
+			InvalidField: field,
+			Details: fmt.Sprintf(
+				"%s must have from 1 to %d letters, digits or '-'", field, maxBarcodeLength,
+			),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package copies
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/errors"
+)
+
+type CreateCopyDomain struct {
+	gateway CreateCopyGateway
+}
+
+func NewCreateCopyDomain(gateway CreateCopyGateway) *CreateCopyDomain {
+	return &CreateCopyDomain{gateway: gateway}
+}
+
+type CreateCopyGateway interface {
+	GetBook(ctx context.Context, shelfName, bookName string, showDeleted bool) (*entities.Book, error)
+	// CreateCopy inserts the copy.
+	// If the copy already exists, returns nil copy and nil error.
+	// If another copy has the same barcode, even a copy of a deleted book, returns AlreadyExistsError.
+	CreateCopy(ctx context.Context, bookCopy *entities.Copy) (*entities.Copy, error)
+}
+
+// CreateCopy adds a copy to a book, which must not be deleted.
+// The condition is required, as only the librarian knows it, while the status defaults to available,
+// as copies are usually on the shelf when they are added. inputCopy is not modified.
+func (c *CreateCopyDomain) CreateCopy(ctx context.Context, inputCopy *entities.Copy) (*entities.Copy, error) {
+	if err := copyKind.ValidateID(inputCopy.Name); err != nil {
+		return nil, err
+	}
+
+	newCopy := *inputCopy
+	if newCopy.Status == "" {
+		newCopy.Status = entities.CopyStatusAvailable
+	}
+
+	if err := validateCopyFields(&newCopy, copyKind.Settable); err != nil {
+		return nil, err
+	}
+
+	book, err := c.gateway.GetBook(ctx, newCopy.ShelfName, newCopy.BookName, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get book from gateway: %w", err)
+	}
+
+	if book == nil {
+		return nil, errors.NotFoundError{
+			Details: fmt.Sprintf("book %s at shelf %s not found", newCopy.BookName, newCopy.ShelfName),
+		}
+	}
+
+	bookCopy, err := c.gateway.CreateCopy(ctx, &newCopy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create copy in gateway: %w", err)
+	}
+
+	if bookCopy == nil {
+		return nil, copyKind.AlreadyExists(copyPath(newCopy.ShelfName, newCopy.BookName, newCopy.Name))
+	}
+
+	return bookCopy, nil
+}
@@ -0,0 +1,33 @@
+package copies
+
+import (
+	"context"
+	"fmt"
+)
+
+type DeleteCopyDomain struct {
+	gateway DeleteCopyGateway
+}
+
+func NewDeleteCopyDomain(gateway DeleteCopyGateway) *DeleteCopyDomain {
+	return &DeleteCopyDomain{gateway: gateway}
+}
+
+// DeleteCopyGateway removes the copy, returning false if it is not found or its book is deleted.
+type DeleteCopyGateway interface {
+	DeleteCopy(ctx context.Context, shelfName, bookName, copyName string) (bool, error)
+}
+
+// DeleteCopy removes the copy from the library permanently, e.g. when it is discarded.
+func (d *DeleteCopyDomain) DeleteCopy(ctx context.Context, shelfName, bookName, copyName string) error {
+	deleted, err := d.gateway.DeleteCopy(ctx, shelfName, bookName, copyName)
+	if err != nil {
+		return fmt.Errorf("failed to delete copy in gateway: %w", err)
+	}
+
+	if !deleted {
+		return copyKind.NotFound(copyPath(shelfName, bookName, copyName))
+	}
+
+	return nil
+}
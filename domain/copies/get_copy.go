@@ -0,0 +1,35 @@
+package copies
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Henrod/library/domain/entities"
+)
+
+type GetCopyDomain struct {
+	gateway GetCopyGateway
+}
+
+func NewGetCopyDomain(gateway GetCopyGateway) *GetCopyDomain {
+	return &GetCopyDomain{gateway: gateway}
+}
+
+// GetCopyGateway returns the copy of the book.
+// If the copy is not found or its book is deleted, returns nil copy and nil error.
+type GetCopyGateway interface {
+	GetCopy(ctx context.Context, shelfName, bookName, copyName string) (*entities.Copy, error)
+}
+
+func (g *GetCopyDomain) GetCopy(ctx context.Context, shelfName, bookName, copyName string) (*entities.Copy, error) {
+	bookCopy, err := g.gateway.GetCopy(ctx, shelfName, bookName, copyName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get copy from gateway: %w", err)
+	}
+
+	if bookCopy == nil {
+		return nil, copyKind.NotFound(copyPath(shelfName, bookName, copyName))
+	}
+
+	return bookCopy, nil
+}
@@ -0,0 +1,80 @@
+package copies
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/errors"
+	"github.com/Henrod/library/domain/filter"
+	"github.com/Henrod/library/domain/orderby"
+	"github.com/Henrod/library/domain/pagination"
+)
+
+type ListCopiesDomain struct {
+	gateway ListCopiesGateway
+}
+
+func NewListCopiesDomain(gateway ListCopiesGateway) *ListCopiesDomain {
+	return &ListCopiesDomain{gateway: gateway}
+}
+
+// ListCopiesGateway lists the copies of the book of page sorted by ordering.
+type ListCopiesGateway interface {
+	GetBook(ctx context.Context, shelfName, bookName string, showDeleted bool) (*entities.Book, error)
+	ListCopies(
+		ctx context.Context,
+		shelfName, bookName string,
+		expr filter.Expr,
+		ordering []orderby.Field,
+		page pagination.Page,
+	) ([]*entities.Copy, error)
+}
+
+// List returns the copies of the book matching the AIP-160 filter sorted by the AIP-132 order_by.
+// Empty filter matches every copy and empty order_by sorts by copy name.
+// The book must not be deleted.
+//
+// The page starts right after its cursor, which is nil for the first page.
+// The returned cursor points to the last copy of the page and is nil when there are no more pages.
+func (l *ListCopiesDomain) List(
+	ctx context.Context,
+	shelfName, bookName string,
+	filterExpr, orderBy string,
+	page pagination.Page,
+) (copies []*entities.Copy, next pagination.Cursor, err error) {
+	query, err := copyKind.ParseList(filterExpr, orderBy, page)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	book, err := l.gateway.GetBook(ctx, shelfName, bookName, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get book from gateway: %w", err)
+	}
+
+	if book == nil {
+		return nil, nil, errors.NotFoundError{
+			Details: fmt.Sprintf("book %s at shelf %s not found", bookName, shelfName),
+		}
+	}
+
+	copies, err = l.gateway.ListCopies(ctx, shelfName, bookName, query.Expr, query.Ordering, query.Page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list copies in gateway: %w", err)
+	}
+
+	if len(copies) <= page.Size {
+		return copies, nil, nil
+	}
+
+	copies = copies[:page.Size]
+	last := copies[len(copies)-1]
+
+	return copies, query.Cursor(map[string]interface{}{
+		"name":        last.Name,
+		"barcode":     last.Barcode,
+		"create_time": last.CreateTime,
+		"update_time": last.UpdateTime,
+	}), nil
+}
@@ -0,0 +1,44 @@
+package copies
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/errors"
+)
+
+type LookupCopyByBarcodeDomain struct {
+	gateway LookupCopyByBarcodeGateway
+}
+
+func NewLookupCopyByBarcodeDomain(gateway LookupCopyByBarcodeGateway) *LookupCopyByBarcodeDomain {
+	return &LookupCopyByBarcodeDomain{gateway: gateway}
+}
+
+// LookupCopyByBarcodeGateway returns the copy with the barcode, from any book.
+// If no copy has the barcode or its book is deleted, returns nil copy and nil error.
+type LookupCopyByBarcodeGateway interface {
+	GetCopyByBarcode(ctx context.Context, barcode string) (*entities.Copy, error)
+}
+
+// LookupCopyByBarcode returns the copy with the scanned barcode, which identifies it in the whole library.
+// Copies of deleted books are not found, though their barcodes stay taken until the books are purged.
+func (l *LookupCopyByBarcodeDomain) LookupCopyByBarcode(ctx context.Context, barcode string) (*entities.Copy, error) {
+	if err := validateBarcode("barcode", barcode); err != nil {
+		return nil, err
+	}
+
+	bookCopy, err := l.gateway.GetCopyByBarcode(ctx, barcode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get copy by barcode from gateway: %w", err)
+	}
+
+	if bookCopy == nil {
+		return nil, errors.NotFoundError{
+			Details: fmt.Sprintf("copy with barcode %s not found", barcode),
+		}
+	}
+
+	return bookCopy, nil
+}
@@ -0,0 +1,51 @@
+package copies
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/Henrod/library/domain/entities"
+)
+
+type UpdateCopyDomain struct {
+	gateway UpdateCopyGateway
+}
+
+func NewUpdateCopyDomain(gateway UpdateCopyGateway) *UpdateCopyDomain {
+	return &UpdateCopyDomain{gateway: gateway}
+}
+
+// UpdateCopyGateway updates only the copy fields in fields.
+// If the copy is not found or its book is deleted, returns nil copy and nil error.
+// If another copy has the same barcode, even a copy of a deleted book, returns AlreadyExistsError.
+type UpdateCopyGateway interface {
+	UpdateCopy(ctx context.Context, bookCopy *entities.Copy, fields []string) (*entities.Copy, error)
+}
+
+func (u *UpdateCopyDomain) UpdateCopy(
+	ctx context.Context,
+	inputCopy *entities.Copy,
+	updateMask *fieldmaskpb.FieldMask,
+) (*entities.Copy, error) {
+	fields, err := copyKind.UpdateFields(updateMask)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateCopyFields(inputCopy, fields); err != nil {
+		return nil, err
+	}
+
+	bookCopy, err := u.gateway.UpdateCopy(ctx, inputCopy, fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update copy in gateway: %w", err)
+	}
+
+	if bookCopy == nil {
+		return nil, copyKind.NotFound(copyPath(inputCopy.ShelfName, inputCopy.BookName, inputCopy.Name))
+	}
+
+	return bookCopy, nil
+}
@@ -0,0 +1,10 @@
+package entities
+
+import "time"
+
+type Author struct {
+	Name        string
+	DisplayName string
+	CreateTime  time.Time
+	UpdateTime  time.Time
+}
@@ -3,9 +3,66 @@ package entities
 import "time"
 
 type Book struct {
-	Name       string
-	Author     string
-	Shelf      *Shelf
-	CreateTime time.Time
-	UpdateTime time.Time
+	Name            string
+	Contributors    []*Contributor // In the order they are credited.
+	AuthorName      string         // ID of the author resource, empty if the book is not linked to one.
+	SeriesName      string         // ID of the series resource, empty if the book is not part of one.
+	VolumeNumber    int            // Number of the book in its series, zero if unknown.
+	WorkName        string         // ID of the work the book is an edition of, empty if it is not linked to one.
+	Title           string
+	ISBN            string // ISBN-10 or ISBN-13, only digits and check digit.
+	Publisher       string
+	PublicationYear int    // Zero if unknown.
+	Language        string // BCP-47 language tag in its canonical form.
+	PageCount       int
+	Labels          map[string]string
+	Description     string
+	Shelf           *Shelf
+	CreateTime      time.Time
+	UpdateTime      time.Time
+	// DeleteTime is zero if the book is not deleted.
+	DeleteTime time.Time
+	// PurgeTime is when a deleted book is removed permanently, zero if the book is not deleted.
+	PurgeTime time.Time
+	// Version changes on every book change, so it identifies the book state.
+	Version int64
+}
+
+// Author is the name of the first author contributor, empty if the book has no author.
+// It is the single author of books created before contributors, still used to search, sort and filter books.
+func (b *Book) Author() string {
+	for _, contributor := range b.Contributors {
+		if contributor.Role == ContributorRoleAuthor {
+			return contributor.Name
+		}
+	}
+
+	return ""
+}
+
+// ReplaceAuthor renames the first author contributor to author, adding it as the first contributor if there is none.
+// Empty author removes the first author.
+func (b *Book) ReplaceAuthor(author string) {
+	contributors := make([]*Contributor, 0, len(b.Contributors)+1)
+	replaced := false
+
+	for _, contributor := range b.Contributors {
+		if replaced || contributor.Role != ContributorRoleAuthor {
+			contributors = append(contributors, contributor)
+
+			continue
+		}
+
+		replaced = true
+
+		if author != "" {
+			contributors = append(contributors, &Contributor{Name: author, Role: ContributorRoleAuthor})
+		}
+	}
+
+	if !replaced && author != "" {
+		contributors = append([]*Contributor{{Name: author, Role: ContributorRoleAuthor}}, contributors...)
+	}
+
+	b.Contributors = contributors
 }
@@ -0,0 +1,11 @@
+package entities
+
+// BookSearchResult is a book matching a search query.
+type BookSearchResult struct {
+	Book *Book
+	// Relevance of the book to the query, higher is more relevant.
+	Relevance float64
+	// Snippet is an HTML excerpt of the book with the matched words between <b> and </b>.
+	// The book text is HTML escaped, so the highlights are its only tags.
+	Snippet string
+}
@@ -0,0 +1,17 @@
+package entities
+
+// SuggestionField is the book field completed by a suggestion.
+type SuggestionField string
+
+const (
+	SuggestionFieldTitle  SuggestionField = "title"
+	SuggestionFieldAuthor SuggestionField = "author"
+)
+
+// BookSuggestion is a title or author completing what the user typed.
+type BookSuggestion struct {
+	Text  string
+	Field SuggestionField
+	// Score is how similar Text is to the typed prefix, from 0 to 1.
+	Score float64
+}
@@ -0,0 +1,17 @@
+package entities
+
+// ContributorRole is how a contributor took part in a book.
+type ContributorRole string
+
+const (
+	ContributorRoleAuthor      ContributorRole = "author"
+	ContributorRoleEditor      ContributorRole = "editor"
+	ContributorRoleTranslator  ContributorRole = "translator"
+	ContributorRoleIllustrator ContributorRole = "illustrator"
+)
+
+// Contributor is a person who took part in a book, e.g. an author or a translator.
+type Contributor struct {
+	Name string
+	Role ContributorRole
+}
@@ -0,0 +1,37 @@
+package entities
+
+import "time"
+
+// CopyCondition is the physical condition of a book copy.
+type CopyCondition string
+
+const (
+	CopyConditionNew     CopyCondition = "new"
+	CopyConditionGood    CopyCondition = "good"
+	CopyConditionFair    CopyCondition = "fair"
+	CopyConditionPoor    CopyCondition = "poor"
+	CopyConditionDamaged CopyCondition = "damaged"
+)
+
+// CopyStatus is whether a book copy can be lent.
+type CopyStatus string
+
+const (
+	CopyStatusAvailable CopyStatus = "available"
+	CopyStatusOnLoan    CopyStatus = "on_loan"
+	CopyStatusInRepair  CopyStatus = "in_repair"
+	CopyStatusLost      CopyStatus = "lost"
+)
+
+// Copy is a physical item of a book, identified by its barcode.
+type Copy struct {
+	Name            string
+	ShelfName       string
+	BookName        string
+	Barcode         string    // Unique in the library.
+	AcquisitionDate time.Time // Date when the copy was acquired at UTC midnight, zero if unknown.
+	Condition       CopyCondition
+	Status          CopyStatus
+	CreateTime      time.Time
+	UpdateTime      time.Time
+}
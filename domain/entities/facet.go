@@ -0,0 +1,13 @@
+package entities
+
+// Facet counts the books of a search per value of Field.
+type Facet struct {
+	Field   string
+	Buckets []*FacetBucket
+}
+
+// FacetBucket is the number of books with a field Value.
+type FacetBucket struct {
+	Value string
+	Count int
+}
@@ -0,0 +1,15 @@
+package entities
+
+import "time"
+
+// Request is a mutation identified by a client request ID, kept to replay its response on retries.
+type Request struct {
+	ID string
+	// RequestHash identifies the request content, so the ID is not reused by a different request.
+	RequestHash []byte
+	// Response is the serialized response, set when the request completes.
+	Response []byte
+	// CompleteTime is zero while the request is in progress.
+	CompleteTime time.Time
+	ExpireTime   time.Time
+}
@@ -0,0 +1,11 @@
+package entities
+
+import "time"
+
+type Series struct {
+	Name        string
+	DisplayName string
+	VolumeCount int // Zero if unknown.
+	CreateTime  time.Time
+	UpdateTime  time.Time
+}
@@ -3,7 +3,9 @@ package entities
 import "time"
 
 type Shelf struct {
-	Name       string
-	CreateTime time.Time
-	UpdateTime time.Time
+	Name        string
+	DisplayName string
+	Labels      map[string]string
+	CreateTime  time.Time
+	UpdateTime  time.Time
 }
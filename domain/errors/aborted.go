@@ -0,0 +1,14 @@
+package errors
+
+import "fmt"
+
+// AbortedError is returned when a concurrent change conflicts with the request,
+// so it may succeed if retried from the latest resource state.
+type AbortedError struct {
+	Reason  string
+	Details string
+}
+
+func (n AbortedError) Error() string {
+	return fmt.Sprintf("aborted: %s", n.Details)
+}
@@ -0,0 +1,18 @@
+package errors
+
+import "fmt"
+
+// BatchItemError is the error of a single item of a batch request.
+// It wraps the item error, so it is still matched as that error type.
+type BatchItemError struct {
+	Index int
+	Err   error
+}
+
+func (b BatchItemError) Error() string {
+	return fmt.Sprintf("batch item %d: %s", b.Index, b.Err)
+}
+
+func (b BatchItemError) Unwrap() error {
+	return b.Err
+}
@@ -0,0 +1,11 @@
+package errors
+
+import "fmt"
+
+type FailedPreconditionError struct {
+	Details string
+}
+
+func (n FailedPreconditionError) Error() string {
+	return fmt.Sprintf("failed precondition: %s", n.Details)
+}
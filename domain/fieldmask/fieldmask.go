@@ -0,0 +1,64 @@
+// Package fieldmask validates the update masks of update methods, following https://google.aip.dev/134.
+package fieldmask
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/Henrod/library/domain/errors"
+)
+
+// Fields returns the fields of updateMask, which must all be in updatable or outputOnly.
+// Output only fields are skipped, so a resource can be sent back as it was read.
+// Returns a BadRequestError on update_mask if it is empty, has another path or has only output only fields.
+func Fields(
+	updateMask *fieldmaskpb.FieldMask,
+	resource string,
+	updatable []string,
+	outputOnly ...string,
+) ([]string, error) {
+	if updateMask == nil {
+		return nil, &errors.BadRequestError{
+			InvalidField: "update_mask",
+			Details:      fmt.Sprintf("update_mask must contain %s fields", resource),
+		}
+	}
+
+	updateMask.Normalize()
+
+	fields := make([]string, 0, len(updateMask.GetPaths()))
+	for _, path := range updateMask.GetPaths() {
+		switch {
+		case Contains(updatable, path):
+			fields = append(fields, path)
+		case Contains(outputOnly, path):
+			continue
+		default:
+			return nil, &errors.BadRequestError{
+				InvalidField: "update_mask",
+				Details:      fmt.Sprintf("update_mask path %q is not an updatable %s field", path, resource),
+			}
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil, &errors.BadRequestError{
+			InvalidField: "update_mask",
+			Details:      "update_mask doesn't have any valid fields to update",
+		}
+	}
+
+	return fields, nil
+}
+
+// Contains reports whether field is one of fields, e.g. of the fields returned by Fields.
+func Contains(fields []string, field string) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+
+	return false
+}
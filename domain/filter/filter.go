@@ -0,0 +1,172 @@
+// Package filter parses list filters that follow https://google.aip.dev/160.
+//
+// The supported grammar is a subset of AIP-160:
+//
+//	expression := sequence { "AND" sequence }
+//	sequence   := factor { factor }
+//	factor     := term { "OR" term }
+//	term       := [ "NOT" | "-" ] simple
+//	simple     := restriction | "(" expression ")"
+//	restriction := field comparator value
+//	field      := name | name "." key
+//	comparator := "=" | "!=" | "<" | "<=" | ">" | ">=" | ":"
+//
+// As in AIP-160, OR has higher precedence than AND.
+// Keys only traverse Map fields, e.g. "labels.genre" compares the genre key of the labels map.
+// The has operator ":" matches maps with a key, e.g. "labels:genre", and present fields with "*",
+// e.g. "labels.genre:*". On a map key it matches the value, e.g. "labels.genre:scifi".
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Henrod/library/domain/errors"
+)
+
+type kind int
+
+const (
+	kindString kind = iota
+	kindInteger
+	kindTimestamp
+	kindMap
+	kindEnum
+)
+
+// Type is the type of a filterable field, used to validate and convert values.
+type Type struct {
+	kind   kind
+	values []string
+}
+
+var (
+	String    = Type{kind: kindString, values: nil}
+	Integer   = Type{kind: kindInteger, values: nil}
+	Timestamp = Type{kind: kindTimestamp, values: nil}
+	// Map is a map of strings, whose values are compared by key.
+	Map = Type{kind: kindMap, values: nil}
+)
+
+// Enum returns the type of a string field whose value is one of values.
+// Values are matched case insensitively, so enums can be filtered by their API names, e.g. status = AVAILABLE,
+// and are only compared by equality.
+func Enum(values ...string) Type {
+	return Type{kind: kindEnum, values: values}
+}
+
+// Fields maps the filterable field names to their types.
+type Fields map[string]Type
+
+type Operator string
+
+const (
+	Equals             Operator = "="
+	NotEquals          Operator = "!="
+	LessThan           Operator = "<"
+	LessThanOrEqual    Operator = "<="
+	GreaterThan        Operator = ">"
+	GreaterThanOrEqual Operator = ">="
+	Has                Operator = ":"
+)
+
+// Expr is a node of the filter abstract syntax tree.
+type Expr interface {
+	expr()
+}
+
+type And struct {
+	Left, Right Expr
+}
+
+type Or struct {
+	Left, Right Expr
+}
+
+type Not struct {
+	Expr Expr
+}
+
+// Comparison restricts Field by Operator and Value.
+// Value is a string, int64 or time.Time, depending on the field Type.
+// Key is the compared key of a Map field, empty for other types.
+// Operator Has has no Value and matches when the field, or the Key of a Map field, is present.
+type Comparison struct {
+	Field    string
+	Key      string
+	Operator Operator
+	Value    interface{}
+}
+
+func (And) expr()        {}
+func (Or) expr()         {}
+func (Not) expr()        {}
+func (Comparison) expr() {}
+
+// Parse returns the AST of filter validated against fields.
+// Empty filter returns nil expression, meaning no restriction.
+// Returns a BadRequestError on the filter field if it is invalid.
+func Parse(filter string, fields Fields) (Expr, error) {
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	p := &parser{tokens: tokens, fields: fields}
+
+	expr, err := p.parseExpression()
+	if err != nil {
+		return nil, badRequest(err)
+	}
+
+	if !p.done() {
+		return nil, badRequest(fmt.Errorf("unexpected %q at position %d", p.peek().text, p.peek().pos))
+	}
+
+	return expr, nil
+}
+
+func convert(field string, fieldType Type, value token) (interface{}, error) {
+	switch fieldType.kind {
+	case kindString, kindMap:
+		return value.text, nil
+	case kindInteger:
+		var i int64
+		if _, err := fmt.Sscan(value.text, &i); err != nil || value.kind != tokenNumber {
+			return nil, fmt.Errorf("field %s expects an integer at position %d, got %q", field, value.pos, value.text)
+		}
+
+		return i, nil
+	case kindTimestamp:
+		t, err := time.Parse(time.RFC3339, value.text)
+		if err != nil {
+			return nil, fmt.Errorf("field %s expects a RFC 3339 timestamp at position %d, got %q",
+				field, value.pos, value.text)
+		}
+
+		return t, nil
+	case kindEnum:
+		for _, v := range fieldType.values {
+			if strings.EqualFold(v, value.text) {
+				return v, nil
+			}
+		}
+
+		return nil, fmt.Errorf("field %s expects one of %s at position %d, got %q",
+			field, strings.Join(fieldType.values, ", "), value.pos, value.text)
+	}
+
+	return nil, fmt.Errorf("field %s has unknown type", field)
+}
+
+func badRequest(err error) error {
+	return &errors.BadRequestError{
+		InvalidField: "filter",
+		Details:      err.Error(),
+	}
+}
@@ -0,0 +1,316 @@
+package filter_test
+
+import (
+	stderrors "errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Henrod/library/domain/errors"
+	"github.com/Henrod/library/domain/filter"
+)
+
+var fields = filter.Fields{
+	"title":       filter.String,
+	"page_count":  filter.Integer,
+	"create_time": filter.Timestamp,
+	"labels":      filter.Map,
+	"status":      filter.Enum("available", "on_loan"),
+}
+
+func eq(field string, value interface{}) filter.Comparison {
+	return filter.Comparison{Field: field, Key: "", Operator: filter.Equals, Value: value}
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	createTime := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter string
+		want   filter.Expr
+	}{
+		{
+			name:   "empty filter",
+			filter: "  ",
+			want:   nil,
+		},
+		{
+			name:   "string comparison",
+			filter: `title = "Dune"`,
+			want:   eq("title", "Dune"),
+		},
+		{
+			name:   "all comparators",
+			filter: "page_count != 1 page_count < 2 page_count <= 3 page_count > 4 page_count >= 5",
+			want: filter.And{
+				Left: filter.And{
+					Left: filter.And{
+						Left: filter.And{
+							Left:  filter.Comparison{Field: "page_count", Key: "", Operator: filter.NotEquals, Value: int64(1)},
+							Right: filter.Comparison{Field: "page_count", Key: "", Operator: filter.LessThan, Value: int64(2)},
+						},
+						Right: filter.Comparison{Field: "page_count", Key: "", Operator: filter.LessThanOrEqual, Value: int64(3)},
+					},
+					Right: filter.Comparison{Field: "page_count", Key: "", Operator: filter.GreaterThan, Value: int64(4)},
+				},
+				Right: filter.Comparison{Field: "page_count", Key: "", Operator: filter.GreaterThanOrEqual, Value: int64(5)},
+			},
+		},
+		{
+			name:   "negative integer",
+			filter: "page_count = -1",
+			want:   eq("page_count", int64(-1)),
+		},
+		{
+			name:   "OR has higher precedence than AND",
+			filter: `title = "a" AND title = "b" OR title = "c"`,
+			want: filter.And{
+				Left:  eq("title", "a"),
+				Right: filter.Or{Left: eq("title", "b"), Right: eq("title", "c")},
+			},
+		},
+		{
+			name:   "OR has higher precedence than implicit AND",
+			filter: `title = "a" OR title = "b" title = "c"`,
+			want: filter.And{
+				Left:  filter.Or{Left: eq("title", "a"), Right: eq("title", "b")},
+				Right: eq("title", "c"),
+			},
+		},
+		{
+			name:   "parenthesis override precedence",
+			filter: `(title = "a" AND title = "b") OR title = "c"`,
+			want: filter.Or{
+				Left:  filter.And{Left: eq("title", "a"), Right: eq("title", "b")},
+				Right: eq("title", "c"),
+			},
+		},
+		{
+			name:   "NOT",
+			filter: `NOT title = "a" AND title = "b"`,
+			want:   filter.And{Left: filter.Not{Expr: eq("title", "a")}, Right: eq("title", "b")},
+		},
+		{
+			name:   "minus negates",
+			filter: `-title = "a"`,
+			want:   filter.Not{Expr: eq("title", "a")},
+		},
+		{
+			name:   "NOT of parenthesis",
+			filter: `NOT (title = "a" OR title = "b")`,
+			want:   filter.Not{Expr: filter.Or{Left: eq("title", "a"), Right: eq("title", "b")}},
+		},
+		{
+			name:   "single quotes",
+			filter: `title = 'Dune'`,
+			want:   eq("title", "Dune"),
+		},
+		{
+			name:   "escaped quotes",
+			filter: `title = "The \"Dune\" saga"`,
+			want:   eq("title", `The "Dune" saga`),
+		},
+		{
+			name:   "quoted keywords are values",
+			filter: `title = "AND OR NOT"`,
+			want:   eq("title", "AND OR NOT"),
+		},
+		{
+			name:   "unquoted text",
+			filter: "title = Dune",
+			want:   eq("title", "Dune"),
+		},
+		{
+			name:   "quoted timestamp",
+			filter: `create_time > "2022-01-01T00:00:00Z"`,
+			want:   filter.Comparison{Field: "create_time", Key: "", Operator: filter.GreaterThan, Value: createTime},
+		},
+		{
+			name:   "unquoted timestamp",
+			filter: "create_time > 2022-01-01T00:00:00Z",
+			want:   filter.Comparison{Field: "create_time", Key: "", Operator: filter.GreaterThan, Value: createTime},
+		},
+		{
+			name:   "timestamp with offset",
+			filter: "create_time <= 2022-01-01T02:00:00+02:00",
+			want: filter.Comparison{
+				Field: "create_time", Key: "", Operator: filter.LessThanOrEqual,
+				Value: time.Date(2022, 1, 1, 2, 0, 0, 0, time.FixedZone("", 2*60*60)),
+			},
+		},
+		{
+			name:   "map key",
+			filter: `labels.genre = "scifi"`,
+			want:   filter.Comparison{Field: "labels", Key: "genre", Operator: filter.Equals, Value: "scifi"},
+		},
+		{
+			name:   "map has key",
+			filter: "labels:genre",
+			want:   filter.Comparison{Field: "labels", Key: "genre", Operator: filter.Has, Value: nil},
+		},
+		{
+			name:   "map key has any value",
+			filter: "labels.genre:*",
+			want:   filter.Comparison{Field: "labels", Key: "genre", Operator: filter.Has, Value: nil},
+		},
+		{
+			name:   "map key has value",
+			filter: `labels.genre:"scifi"`,
+			want:   filter.Comparison{Field: "labels", Key: "genre", Operator: filter.Equals, Value: "scifi"},
+		},
+		{
+			name:   "field has any value",
+			filter: "NOT title:*",
+			want:   filter.Not{Expr: filter.Comparison{Field: "title", Key: "", Operator: filter.Has, Value: nil}},
+		},
+		{
+			name:   "enum",
+			filter: `status = "available"`,
+			want:   eq("status", "available"),
+		},
+		{
+			name:   "enum by API name",
+			filter: "status != ON_LOAN",
+			want:   filter.Comparison{Field: "status", Key: "", Operator: filter.NotEquals, Value: "on_loan"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := filter.Parse(tt.filter, fields)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.filter, err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) = %#v, want %#v", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		filter  string
+		details string
+	}{
+		{
+			name:    "unknown field",
+			filter:  `title = "a" AND author = "b"`,
+			details: `field "author" at position 16 is not filterable`,
+		},
+		{
+			name:    "unknown map",
+			filter:  `genre.scifi = "b"`,
+			details: `field "genre.scifi" at position 0 is not filterable`,
+		},
+		{
+			name:    "map without key",
+			filter:  `labels = "scifi"`,
+			details: `field "labels" at position 0 must be compared by key, e.g. labels.key`,
+		},
+		{
+			name:    "missing comparator",
+			filter:  `title "a"`,
+			details: `expected comparator after title at position 6, got "a"`,
+		},
+		{
+			name:    "missing value",
+			filter:  `title = )`,
+			details: `expected value at position 8, got ")"`,
+		},
+		{
+			name:    "unexpected end",
+			filter:  `title =`,
+			details: "unexpected end of filter",
+		},
+		{
+			name:    "unterminated string",
+			filter:  `title = "Dune`,
+			details: "unterminated string starting at position 8",
+		},
+		{
+			name:    "unexpected character",
+			filter:  `title = "a" & title = "b"`,
+			details: `unexpected "&" at position 12`,
+		},
+		{
+			name:    "lone exclamation mark",
+			filter:  `title ! "a"`,
+			details: `unexpected "!" at position 6`,
+		},
+		{
+			name:    "missing closing parenthesis",
+			filter:  `(title = "a"`,
+			details: "missing closing parenthesis for position 0",
+		},
+		{
+			name:    "unexpected closing parenthesis",
+			filter:  `title = "a")`,
+			details: `unexpected ")" at position 11`,
+		},
+		{
+			name:    "trailing AND",
+			filter:  `title = "a" AND`,
+			details: "unexpected end of filter",
+		},
+		{
+			name:    "value instead of field",
+			filter:  `"title" = "a"`,
+			details: `expected field name at position 0, got "title"`,
+		},
+		{
+			name:    "invalid integer",
+			filter:  `page_count = "ten"`,
+			details: `field page_count expects an integer at position 13, got "ten"`,
+		},
+		{
+			name:    "invalid timestamp",
+			filter:  `create_time > "2022-01-01"`,
+			details: `field create_time expects a RFC 3339 timestamp at position 14, got "2022-01-01"`,
+		},
+		{
+			name:    "invalid enum",
+			filter:  `status = lent`,
+			details: `field status expects one of available, on_loan at position 9, got "lent"`,
+		},
+		{
+			name:    "enum ordering",
+			filter:  `status > "available"`,
+			details: "field status at position 0 only supports = and !=",
+		},
+		{
+			name:    "has value on non map field",
+			filter:  `title:"Dune"`,
+			details: `field title at position 0 only supports the has operator with *, got "Dune"`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := filter.Parse(tt.filter, fields)
+
+			var badRequest *errors.BadRequestError
+			if !stderrors.As(err, &badRequest) {
+				t.Fatalf("Parse(%q) returned %v, want a BadRequestError", tt.filter, err)
+			}
+
+			if badRequest.InvalidField != "filter" || badRequest.Details != tt.details {
+				t.Errorf("Parse(%q) returned %s: %q, want filter: %q",
+					tt.filter, badRequest.InvalidField, badRequest.Details, tt.details)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenText tokenKind = iota
+	tokenString
+	tokenNumber
+	tokenComparator
+	tokenLeftParen
+	tokenRightParen
+	tokenAnd
+	tokenOr
+	tokenNot
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLeftParen, text: "(", pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRightParen, text: ")", pos: i})
+			i++
+		case r == '-' && (i+1 >= len(runes) || !unicode.IsDigit(runes[i+1])):
+			tokens = append(tokens, token{kind: tokenNot, text: "-", pos: i})
+			i++
+		case strings.ContainsRune("=!<>", r):
+			end := i + 1
+			if end < len(runes) && runes[end] == '=' {
+				end++
+			}
+
+			text := string(runes[i:end])
+			if text == "!" {
+				return nil, fmt.Errorf("unexpected %q at position %d", text, i)
+			}
+
+			tokens = append(tokens, token{kind: tokenComparator, text: text, pos: i})
+			i = end
+		case r == ':' && !afterComparator(tokens):
+			tokens = append(tokens, token{kind: tokenComparator, text: ":", pos: i})
+			i++
+		case r == '"' || r == '\'':
+			text, end, err := readString(runes, i)
+			if err != nil {
+				return nil, err
+			}
+
+			tokens = append(tokens, token{kind: tokenString, text: text, pos: i})
+			i = end
+		default:
+			// Colons of values, e.g. unquoted timestamps, are text, while a colon after a field is the has operator.
+			value := afterComparator(tokens)
+
+			end := i
+			for end < len(runes) && isTextRune(runes[end]) && (value || runes[end] != ':') {
+				end++
+			}
+
+			if end == i {
+				return nil, fmt.Errorf("unexpected %q at position %d", string(r), i)
+			}
+
+			tokens = append(tokens, textToken(string(runes[i:end]), i))
+			i = end
+		}
+	}
+
+	return tokens, nil
+}
+
+func readString(runes []rune, start int) (string, int, error) {
+	quote := runes[start]
+
+	var builder strings.Builder
+	for i := start + 1; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			if i+1 < len(runes) {
+				i++
+				builder.WriteRune(runes[i])
+			}
+		case quote:
+			return builder.String(), i + 1, nil
+		default:
+			builder.WriteRune(runes[i])
+		}
+	}
+
+	return "", 0, fmt.Errorf("unterminated string starting at position %d", start)
+}
+
+func isTextRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_.-:+*", r)
+}
+
+func afterComparator(tokens []token) bool {
+	return len(tokens) > 0 && tokens[len(tokens)-1].kind == tokenComparator
+}
+
+func textToken(text string, pos int) token {
+	switch text {
+	case "AND":
+		return token{kind: tokenAnd, text: text, pos: pos}
+	case "OR":
+		return token{kind: tokenOr, text: text, pos: pos}
+	case "NOT":
+		return token{kind: tokenNot, text: text, pos: pos}
+	}
+
+	isNumber := true
+	for i, r := range text {
+		if !unicode.IsDigit(r) && !(i == 0 && r == '-') {
+			isNumber = false
+
+			break
+		}
+	}
+
+	if isNumber {
+		return token{kind: tokenNumber, text: text, pos: pos}
+	}
+
+	return token{kind: tokenText, text: text, pos: pos}
+}
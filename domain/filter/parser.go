@@ -0,0 +1,244 @@
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var errUnexpectedEnd = errors.New("unexpected end of filter")
+
+var operators = map[Operator]struct{}{
+	Equals:             {},
+	NotEquals:          {},
+	LessThan:           {},
+	LessThanOrEqual:    {},
+	GreaterThan:        {},
+	GreaterThanOrEqual: {},
+	Has:                {},
+}
+
+type parser struct {
+	tokens []token
+	index  int
+	fields Fields
+}
+
+func (p *parser) done() bool {
+	return p.index >= len(p.tokens)
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.index]
+}
+
+func (p *parser) next() (token, error) {
+	if p.done() {
+		return token{}, errUnexpectedEnd
+	}
+
+	t := p.tokens[p.index]
+	p.index++
+
+	return t, nil
+}
+
+func (p *parser) parseExpression() (Expr, error) {
+	left, err := p.parseSequence()
+	if err != nil {
+		return nil, err
+	}
+
+	for !p.done() && p.peek().kind == tokenAnd {
+		p.index++
+
+		right, err := p.parseSequence()
+		if err != nil {
+			return nil, err
+		}
+
+		left = And{Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+// parseSequence parses implicit AND of factors separated by whitespace.
+func (p *parser) parseSequence() (Expr, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+
+	for !p.done() && p.startsTerm() {
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+
+		left = And{Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) startsTerm() bool {
+	switch p.peek().kind {
+	case tokenText, tokenNot, tokenLeftParen:
+		return true
+	case tokenString, tokenNumber, tokenComparator, tokenRightParen, tokenAnd, tokenOr:
+		return false
+	}
+
+	return false
+}
+
+func (p *parser) parseFactor() (Expr, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+
+	for !p.done() && p.peek().kind == tokenOr {
+		p.index++
+
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+
+		left = Or{Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseTerm() (Expr, error) {
+	if !p.done() && p.peek().kind == tokenNot {
+		p.index++
+
+		expr, err := p.parseSimple()
+		if err != nil {
+			return nil, err
+		}
+
+		return Not{Expr: expr}, nil
+	}
+
+	return p.parseSimple()
+}
+
+func (p *parser) parseSimple() (Expr, error) {
+	t, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t.kind {
+	case tokenLeftParen:
+		expr, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+
+		closing, err := p.next()
+		if err != nil || closing.kind != tokenRightParen {
+			return nil, fmt.Errorf("missing closing parenthesis for position %d", t.pos)
+		}
+
+		return expr, nil
+	case tokenText:
+		return p.parseRestriction(t)
+	case tokenString, tokenNumber, tokenComparator, tokenRightParen, tokenAnd, tokenOr, tokenNot:
+	}
+
+	return nil, fmt.Errorf("expected field name at position %d, got %q", t.pos, t.text)
+}
+
+func (p *parser) parseRestriction(field token) (Expr, error) {
+	name, key, fieldType, err := p.resolveField(field)
+	if err != nil {
+		return nil, err
+	}
+
+	comparator, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := operators[Operator(comparator.text)]; !ok || comparator.kind != tokenComparator {
+		return nil, fmt.Errorf("expected comparator after %s at position %d, got %q",
+			field.text, comparator.pos, comparator.text)
+	}
+
+	value, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	if value.kind != tokenString && value.kind != tokenText && value.kind != tokenNumber {
+		return nil, fmt.Errorf("expected value at position %d, got %q", value.pos, value.text)
+	}
+
+	operator := Operator(comparator.text)
+
+	switch {
+	case operator == Has:
+		return has(field, name, key, fieldType, value)
+	case fieldType.kind == kindMap:
+		return nil, fmt.Errorf("field %q at position %d must be compared by key, e.g. %s.key",
+			field.text, field.pos, field.text)
+	case fieldType.kind == kindEnum && operator != Equals && operator != NotEquals:
+		return nil, fmt.Errorf("field %s at position %d only supports = and !=", field.text, field.pos)
+	}
+
+	converted, err := convert(field.text, fieldType, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return Comparison{
+		Field:    name,
+		Key:      key,
+		Operator: operator,
+		Value:    converted,
+	}, nil
+}
+
+// has returns the restriction of the has operator.
+// A Map has a key, e.g. labels:genre, and any field is present with "*", e.g. labels.genre:*.
+// Other values only match map keys, by equality, e.g. labels.genre:scifi.
+func has(field token, name, key string, fieldType Type, value token) (Expr, error) {
+	isAny := value.kind == tokenText && value.text == "*"
+
+	switch {
+	case isAny:
+		return Comparison{Field: name, Key: key, Operator: Has, Value: nil}, nil
+	case fieldType.kind == kindMap:
+		return Comparison{Field: name, Key: value.text, Operator: Has, Value: nil}, nil
+	case key != "":
+		return Comparison{Field: name, Key: key, Operator: Equals, Value: value.text}, nil
+	}
+
+	return nil, fmt.Errorf("field %s at position %d only supports the has operator with *, got %q",
+		field.text, field.pos, value.text)
+}
+
+// resolveField returns the name, key and type of the compared field.
+// Map fields are compared by key, e.g. "labels.genre", and their values are strings.
+func (p *parser) resolveField(field token) (name, key string, fieldType Type, err error) {
+	if fieldType, ok := p.fields[field.text]; ok {
+		return field.text, "", fieldType, nil
+	}
+
+	name, key = field.text, ""
+	if i := strings.Index(field.text, "."); i >= 0 {
+		name, key = field.text[:i], field.text[i+1:]
+	}
+
+	if fieldType, ok := p.fields[name]; ok && fieldType.kind == kindMap && key != "" {
+		return name, key, String, nil
+	}
+
+	return "", "", Type{}, fmt.Errorf("field %q at position %d is not filterable", field.text, field.pos)
+}
@@ -0,0 +1,135 @@
+// Package idempotency executes mutations at most once per client request ID,
+// following https://google.aip.dev/155.
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/errors"
+	"go.uber.org/zap"
+)
+
+// inProgressRetention is how long a request is reserved while executing.
+// If the server stops before the request completes, its ID can be reused after it.
+const inProgressRetention = time.Minute
+
+var uuidRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+type IdempotencyDomain struct {
+	gateway   IdempotencyGateway
+	retention time.Duration
+	log       *zap.SugaredLogger
+}
+
+// NewIdempotencyDomain returns the IdempotencyDomain.
+// Responses are replayed for retention after the request completes.
+func NewIdempotencyDomain(
+	log *zap.SugaredLogger,
+	gateway IdempotencyGateway,
+	retention time.Duration,
+) *IdempotencyDomain {
+	return &IdempotencyDomain{gateway: gateway, retention: retention, log: log}
+}
+
+// IdempotencyGateway stores the requests until they expire.
+// Expired requests are the same as requests that don't exist.
+type IdempotencyGateway interface {
+	// CreateRequest returns nil request and nil error if a request with the same ID exists.
+	CreateRequest(ctx context.Context, request *entities.Request) (*entities.Request, error)
+	// GetRequest returns nil request and nil error if the request doesn't exist.
+	GetRequest(ctx context.Context, requestID string) (*entities.Request, error)
+	CompleteRequest(ctx context.Context, requestID string, response []byte, expireTime time.Time) error
+	DeleteRequest(ctx context.Context, requestID string) error
+}
+
+// Execute runs execute once per request ID and returns its serialized response.
+// Retries with the same request ID and hash return the original response without running execute again.
+// Errors are not stored, so a failed request is executed again when retried.
+//
+// If requestID is empty, execute is always run.
+// If requestID is not a UUID or was used by a request with a different hash, completed or not,
+// returns BadRequestError, and if the same request is still in progress, returns AbortedError.
+// Errors of execute are returned as they are.
+func (i *IdempotencyDomain) Execute(
+	ctx context.Context,
+	requestID string,
+	requestHash []byte,
+	execute func(ctx context.Context) ([]byte, error),
+) ([]byte, error) {
+	if requestID == "" {
+		return execute(ctx)
+	}
+
+	if !uuidRegexp.MatchString(requestID) {
+		return nil, &errors.BadRequestError{
+			InvalidField: "request_id",
+			Details:      "request_id must be a UUID",
+		}
+	}
+
+	request, err := i.gateway.CreateRequest(ctx, &entities.Request{
+		ID:           requestID,
+		RequestHash:  requestHash,
+		Response:     nil,
+		CompleteTime: time.Time{},
+		ExpireTime:   time.Now().Add(inProgressRetention),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request in gateway: %w", err)
+	}
+
+	if request == nil {
+		return i.replay(ctx, requestID, requestHash)
+	}
+
+	response, err := execute(ctx)
+	if err != nil {
+		i.deleteRequest(ctx, requestID)
+
+		return nil, err
+	}
+
+	err = i.gateway.CompleteRequest(ctx, requestID, response, time.Now().Add(i.retention))
+	if err != nil {
+		// The request succeeded, so only its retries are affected.
+		i.log.With(zap.Error(err), zap.String("request_id", requestID)).Error("failed to complete request in gateway")
+		i.deleteRequest(ctx, requestID)
+	}
+
+	return response, nil
+}
+
+// replay returns the response of the existing request with requestID.
+func (i *IdempotencyDomain) replay(ctx context.Context, requestID string, requestHash []byte) ([]byte, error) {
+	request, err := i.gateway.GetRequest(ctx, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get request from gateway: %w", err)
+	}
+
+	if request != nil && !bytes.Equal(request.RequestHash, requestHash) {
+		return nil, &errors.BadRequestError{
+			InvalidField: "request_id",
+			Details:      fmt.Sprintf("request_id %s was used by a different request", requestID),
+		}
+	}
+
+	if request == nil || request.CompleteTime.IsZero() {
+		return nil, errors.AbortedError{
+			Reason:  "REQUEST_IN_PROGRESS",
+			Details: fmt.Sprintf("request %s is in progress, retry later", requestID),
+		}
+	}
+
+	return request.Response, nil
+}
+
+func (i *IdempotencyDomain) deleteRequest(ctx context.Context, requestID string) {
+	if err := i.gateway.DeleteRequest(ctx, requestID); err != nil {
+		i.log.With(zap.Error(err), zap.String("request_id", requestID)).Error("failed to delete request in gateway")
+	}
+}
@@ -0,0 +1,287 @@
+package idempotency_test
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/errors"
+	"github.com/Henrod/library/domain/idempotency"
+)
+
+const requestID = "6f1c2a4e-8d3b-4f5a-9c7e-1b2d3e4f5a6b"
+
+var errExecute = stderrors.New("execute failed")
+
+// fakeGateway stores the requests in memory.
+type fakeGateway struct {
+	mu       sync.Mutex
+	requests map[string]*entities.Request
+}
+
+func newFakeGateway(requests ...*entities.Request) *fakeGateway {
+	gateway := &fakeGateway{mu: sync.Mutex{}, requests: map[string]*entities.Request{}}
+	for _, request := range requests {
+		gateway.requests[request.ID] = request
+	}
+
+	return gateway
+}
+
+func (f *fakeGateway) CreateRequest(_ context.Context, request *entities.Request) (*entities.Request, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.requests[request.ID]; ok {
+		return nil, nil
+	}
+
+	stored := *request
+	f.requests[request.ID] = &stored
+
+	return request, nil
+}
+
+func (f *fakeGateway) GetRequest(_ context.Context, requestID string) (*entities.Request, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.requests[requestID], nil
+}
+
+func (f *fakeGateway) CompleteRequest(_ context.Context, requestID string, response []byte, expireTime time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	request := f.requests[requestID]
+	request.Response = response
+	request.CompleteTime = time.Now()
+	request.ExpireTime = expireTime
+
+	return nil
+}
+
+func (f *fakeGateway) DeleteRequest(_ context.Context, requestID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.requests, requestID)
+
+	return nil
+}
+
+func isBadRequest(err error) bool {
+	var badRequest *errors.BadRequestError
+
+	return stderrors.As(err, &badRequest) && badRequest.InvalidField == "request_id"
+}
+
+func isInProgress(err error) bool {
+	var aborted errors.AbortedError
+
+	return stderrors.As(err, &aborted) && aborted.Reason == "REQUEST_IN_PROGRESS"
+}
+
+func isExecuteError(err error) bool {
+	return stderrors.Is(err, errExecute)
+}
+
+func TestExecute(t *testing.T) {
+	t.Parallel()
+
+	hash := []byte("hash")
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		requestID string
+		existing  []*entities.Request
+		// executeErr fails the execution, which returns the response "executed" otherwise.
+		executeErr   error
+		wantResponse []byte
+		wantErr      func(error) bool
+		wantExecuted bool
+		// wantStored is the response stored for the request ID after the execution, nil if not stored.
+		wantStored []byte
+	}{
+		{
+			name:         "new request",
+			requestID:    requestID,
+			existing:     nil,
+			executeErr:   nil,
+			wantResponse: []byte("executed"),
+			wantErr:      nil,
+			wantExecuted: true,
+			wantStored:   []byte("executed"),
+		},
+		{
+			name:      "replay of a completed request",
+			requestID: requestID,
+			existing: []*entities.Request{{
+				ID:           requestID,
+				RequestHash:  hash,
+				Response:     []byte("original"),
+				CompleteTime: now,
+				ExpireTime:   now.Add(time.Hour),
+			}},
+			executeErr:   nil,
+			wantResponse: []byte("original"),
+			wantErr:      nil,
+			wantExecuted: false,
+			wantStored:   []byte("original"),
+		},
+		{
+			name:      "completed request with a different hash",
+			requestID: requestID,
+			existing: []*entities.Request{{
+				ID:           requestID,
+				RequestHash:  []byte("other hash"),
+				Response:     []byte("original"),
+				CompleteTime: now,
+				ExpireTime:   now.Add(time.Hour),
+			}},
+			executeErr:   nil,
+			wantResponse: nil,
+			wantErr:      isBadRequest,
+			wantExecuted: false,
+			wantStored:   []byte("original"),
+		},
+		{
+			name:      "in progress request with a different hash",
+			requestID: requestID,
+			existing: []*entities.Request{{
+				ID:           requestID,
+				RequestHash:  []byte("other hash"),
+				Response:     nil,
+				CompleteTime: time.Time{},
+				ExpireTime:   now.Add(time.Minute),
+			}},
+			executeErr:   nil,
+			wantResponse: nil,
+			wantErr:      isBadRequest,
+			wantExecuted: false,
+			wantStored:   nil,
+		},
+		{
+			name:      "in progress request",
+			requestID: requestID,
+			existing: []*entities.Request{{
+				ID:           requestID,
+				RequestHash:  hash,
+				Response:     nil,
+				CompleteTime: time.Time{},
+				ExpireTime:   now.Add(time.Minute),
+			}},
+			executeErr:   nil,
+			wantResponse: nil,
+			wantErr:      isInProgress,
+			wantExecuted: false,
+			wantStored:   nil,
+		},
+		{
+			name:         "failed request is deleted",
+			requestID:    requestID,
+			existing:     nil,
+			executeErr:   errExecute,
+			wantResponse: nil,
+			wantErr:      isExecuteError,
+			wantExecuted: true,
+			wantStored:   nil,
+		},
+		{
+			name:         "empty request ID",
+			requestID:    "",
+			existing:     nil,
+			executeErr:   nil,
+			wantResponse: []byte("executed"),
+			wantErr:      nil,
+			wantExecuted: true,
+			wantStored:   nil,
+		},
+		{
+			name:         "request ID is not a UUID",
+			requestID:    "request1",
+			existing:     nil,
+			executeErr:   nil,
+			wantResponse: nil,
+			wantErr:      isBadRequest,
+			wantExecuted: false,
+			wantStored:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gateway := newFakeGateway(tt.existing...)
+			domain := idempotency.NewIdempotencyDomain(zap.NewNop().Sugar(), gateway, time.Hour)
+
+			executed := false
+			response, err := domain.Execute(context.Background(), tt.requestID, hash,
+				func(ctx context.Context) ([]byte, error) {
+					executed = true
+					if tt.executeErr != nil {
+						return nil, tt.executeErr
+					}
+
+					return []byte("executed"), nil
+				},
+			)
+
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("Execute returned %v", err)
+			}
+
+			if tt.wantErr != nil && !tt.wantErr(err) {
+				t.Fatalf("Execute returned %v, want another error", err)
+			}
+
+			if !bytes.Equal(response, tt.wantResponse) {
+				t.Errorf("Execute = %q, want %q", response, tt.wantResponse)
+			}
+
+			if executed != tt.wantExecuted {
+				t.Errorf("executed = %t, want %t", executed, tt.wantExecuted)
+			}
+
+			var stored []byte
+			if request, _ := gateway.GetRequest(context.Background(), tt.requestID); request != nil {
+				stored = request.Response
+			}
+
+			if !bytes.Equal(stored, tt.wantStored) {
+				t.Errorf("stored response = %q, want %q", stored, tt.wantStored)
+			}
+		})
+	}
+}
+
+func TestExecuteRetriesFailedRequest(t *testing.T) {
+	t.Parallel()
+
+	gateway := newFakeGateway()
+	domain := idempotency.NewIdempotencyDomain(zap.NewNop().Sugar(), gateway, time.Hour)
+	hash := []byte("hash")
+
+	_, err := domain.Execute(context.Background(), requestID, hash, func(ctx context.Context) ([]byte, error) {
+		return nil, errExecute
+	})
+	if !stderrors.Is(err, errExecute) {
+		t.Fatalf("Execute returned %v, want %v", err, errExecute)
+	}
+
+	response, err := domain.Execute(context.Background(), requestID, hash, func(ctx context.Context) ([]byte, error) {
+		return []byte("retried"), nil
+	})
+	if err != nil || !bytes.Equal(response, []byte("retried")) {
+		t.Errorf("Execute retry = %q, %v, want the retried response", response, err)
+	}
+}
@@ -0,0 +1,78 @@
+// Package labels validates the free-form labels of books and shelves.
+//
+// Labels follow the same rules as Google Cloud labels:
+// - a resource has at most 64 labels;
+// - keys start with a lowercase letter followed by lowercase letters, digits, '_' or '-';
+// - values may be empty and have only lowercase letters, digits, '_' or '-';
+// - keys and values have at most 63 characters.
+package labels
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/Henrod/library/domain/errors"
+)
+
+// MaxLabels is the maximum number of labels of a resource.
+const MaxLabels = 64
+
+// maxLength is the maximum number of characters of label keys and values.
+const maxLength = 63
+
+var (
+	keyRegexp   = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+	valueRegexp = regexp.MustCompile(`^[a-z0-9_-]*$`)
+)
+
+// Validate returns a BadRequestError on field if labels break any of the label rules.
+// Keys are validated in order, so the same labels always report the same violation.
+func Validate(field string, labels map[string]string) error {
+	if len(labels) > MaxLabels {
+		return &errors.BadRequestError{
+			InvalidField: field,
+			Details:      fmt.Sprintf("%s must have at most %d labels", field, MaxLabels),
+		}
+	}
+
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := validateKey(field, key); err != nil {
+			return err
+		}
+
+		value := labels[key]
+		if len(value) > maxLength || !valueRegexp.MatchString(value) {
+			return &errors.BadRequestError{
+				InvalidField: fmt.Sprintf("%s[%s]", field, key),
+				Details: fmt.Sprintf(
+					"%s[%s] value must have at most %d lowercase letters, digits, '_' or '-'", field, key, maxLength,
+				),
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateKey returns a BadRequestError on field if key is not a valid label key.
+func validateKey(field, key string) error {
+	if len(key) > maxLength || !keyRegexp.MatchString(key) {
+		return &errors.BadRequestError{
+			InvalidField: field,
+			Details: fmt.Sprintf(
+				"%s key %q must start with a lowercase letter followed by at most %d lowercase letters, digits, '_' or '-'",
+				field, key, maxLength-1,
+			),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+// Package orderby parses list orderings that follow https://google.aip.dev/132#ordering.
+//
+// An ordering is a comma separated list of fields, each optionally followed by
+// "desc" for descending order, e.g. "author desc, create_time".
+package orderby
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Henrod/library/domain/errors"
+)
+
+// Fields are the field names a resource can be sorted by.
+type Fields map[string]struct{}
+
+// Field is a sort key of an ordering.
+type Field struct {
+	Name       string
+	Descending bool
+}
+
+// Parse returns the sort keys of orderBy validated against fields.
+// Empty orderBy returns no sort keys, meaning default order.
+// Returns a BadRequestError on the order_by field if it is invalid.
+func Parse(orderBy string, fields Fields) ([]Field, error) {
+	if strings.TrimSpace(orderBy) == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(orderBy, ",")
+	ordering := make([]Field, 0, len(parts))
+	seen := make(map[string]struct{}, len(parts))
+
+	for _, part := range parts {
+		words := strings.Fields(part)
+		if len(words) == 0 || len(words) > 2 {
+			return nil, badRequest(fmt.Sprintf("invalid sort key %q, must be \"field\" or \"field desc\"", part))
+		}
+
+		name := words[0]
+		if _, ok := fields[name]; !ok {
+			return nil, badRequest(fmt.Sprintf("field %q is not sortable", name))
+		}
+
+		if _, ok := seen[name]; ok {
+			return nil, badRequest(fmt.Sprintf("field %q is repeated", name))
+		}
+
+		seen[name] = struct{}{}
+
+		descending := false
+		if len(words) == 2 {
+			switch words[1] {
+			case "desc":
+				descending = true
+			case "asc":
+			default:
+				return nil, badRequest(fmt.Sprintf("invalid direction %q for field %q, must be \"asc\" or \"desc\"",
+					words[1], name))
+			}
+		}
+
+		ordering = append(ordering, Field{Name: name, Descending: descending})
+	}
+
+	return ordering, nil
+}
+
+func badRequest(details string) error {
+	return &errors.BadRequestError{
+		InvalidField: "order_by",
+		Details:      details,
+	}
+}
+
+// WithTiebreakers appends to ordering, in ascending order, the keys not sorted by it yet.
+// When keys uniquely identify a resource, the resulting ordering is deterministic.
+func WithTiebreakers(ordering []Field, keys ...string) []Field {
+	sorted := make(map[string]struct{}, len(ordering))
+	for _, field := range ordering {
+		sorted[field.Name] = struct{}{}
+	}
+
+	result := append(make([]Field, 0, len(ordering)+len(keys)), ordering...)
+
+	for _, key := range keys {
+		if _, ok := sorted[key]; ok {
+			continue
+		}
+
+		result = append(result, Field{Name: key, Descending: false})
+	}
+
+	return result
+}
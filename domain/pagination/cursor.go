@@ -0,0 +1,76 @@
+// Package pagination has the keyset cursor used to paginate list methods.
+package pagination
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Cursor is the sort key of the last item returned in a page,
+// with one value per sort field of the list ordering.
+// The next page starts right after it, so pages never overlap nor skip items.
+// Values are string, int64, float64 or time.Time.
+type Cursor []interface{}
+
+// cursorValue keeps the value type when the cursor is serialized.
+type cursorValue struct {
+	String *string    `json:"s,omitempty"`
+	Int    *int64     `json:"i,omitempty"`
+	Float  *float64   `json:"f,omitempty"`
+	Time   *time.Time `json:"t,omitempty"`
+}
+
+func (c Cursor) MarshalJSON() ([]byte, error) {
+	values := make([]cursorValue, len(c))
+
+	for i, value := range c {
+		switch v := value.(type) {
+		case string:
+			values[i].String = &v
+		case int64:
+			values[i].Int = &v
+		case float64:
+			values[i].Float = &v
+		case time.Time:
+			values[i].Time = &v
+		default:
+			return nil, fmt.Errorf("unsupported cursor value type %T", value)
+		}
+	}
+
+	b, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal cursor values: %w", err)
+	}
+
+	return b, nil
+}
+
+func (c *Cursor) UnmarshalJSON(b []byte) error {
+	var values []cursorValue
+	if err := json.Unmarshal(b, &values); err != nil {
+		return fmt.Errorf("failed to unmarshal cursor values: %w", err)
+	}
+
+	cursor := make(Cursor, len(values))
+
+	for i, value := range values {
+		switch {
+		case value.String != nil:
+			cursor[i] = *value.String
+		case value.Int != nil:
+			cursor[i] = *value.Int
+		case value.Float != nil:
+			cursor[i] = *value.Float
+		case value.Time != nil:
+			cursor[i] = *value.Time
+		default:
+			return fmt.Errorf("cursor value %d has no type", i)
+		}
+	}
+
+	*c = cursor
+
+	return nil
+}
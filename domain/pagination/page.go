@@ -0,0 +1,11 @@
+package pagination
+
+// Page selects the items of a list page.
+type Page struct {
+	// After is the cursor of the last item of the previous page, nil for the first page.
+	After Cursor
+	// Skip is the number of items skipped right after the cursor.
+	Skip int
+	// Size is the maximum number of items in the page.
+	Size int
+}
@@ -0,0 +1,299 @@
+// Package resourcename parses and formats the library resource names, following https://google.aip.dev/122.
+//
+// Supported patterns are:
+// - shelves/{shelf}
+// - shelves/{shelf}/books/{book}
+// - shelves/{shelf}/books/{book}/copies/{copy}
+// - authors/{author}
+// - series/{series}
+// - works/{work}
+// - operations/shelves/{shelf} and operations/shelves/{shelf}/delete
+//
+// Parse functions return a BadRequestError on the given field if the name doesn't match the pattern
+// or any of its IDs is invalid.
+package resourcename
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Henrod/library/domain/errors"
+)
+
+// Wildcard replaces an ID to refer to every resource of a collection, e.g. "shelves/-".
+const Wildcard = "-"
+
+// MaxIDLength is the maximum number of characters of a resource ID.
+const MaxIDLength = 255
+
+const (
+	shelfPattern           = "shelves/{shelf}"
+	bookPattern            = "shelves/{shelf}/books/{book}"
+	copyPattern            = "shelves/{shelf}/books/{book}/copies/{copy}"
+	authorPattern          = "authors/{author}"
+	seriesPattern          = "series/{series}"
+	workPattern            = "works/{work}"
+	operationPattern       = "operations/shelves/{shelf}"
+	deleteOperationPattern = "operations/shelves/{shelf}/delete"
+	deleteOperationSuffix  = "delete"
+)
+
+// idRegexp is the pattern of resource IDs: URL-safe characters starting with a letter or digit,
+// so IDs are never confused with the wildcard.
+var idRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._~-]*$`)
+
+// Shelf is the name of a shelf: shelves/{shelf}.
+type Shelf struct {
+	Shelf string
+}
+
+func (s Shelf) String() string {
+	return "shelves/" + s.Shelf
+}
+
+// Book is the name of a book: shelves/{shelf}/books/{book}.
+type Book struct {
+	Shelf string
+	Book  string
+}
+
+func (b Book) String() string {
+	return fmt.Sprintf("shelves/%s/books/%s", b.Shelf, b.Book)
+}
+
+// Parent returns the name of the shelf of the book.
+func (b Book) Parent() Shelf {
+	return Shelf{Shelf: b.Shelf}
+}
+
+// Copy is the name of a physical copy of a book: shelves/{shelf}/books/{book}/copies/{copy}.
+type Copy struct {
+	Shelf string
+	Book  string
+	Copy  string
+}
+
+func (c Copy) String() string {
+	return fmt.Sprintf("shelves/%s/books/%s/copies/%s", c.Shelf, c.Book, c.Copy)
+}
+
+// Parent returns the name of the book of the copy.
+func (c Copy) Parent() Book {
+	return Book{Shelf: c.Shelf, Book: c.Book}
+}
+
+// Author is the name of an author: authors/{author}.
+type Author struct {
+	Author string
+}
+
+func (a Author) String() string {
+	return "authors/" + a.Author
+}
+
+// Series is the name of a series: series/{series}.
+type Series struct {
+	Series string
+}
+
+func (s Series) String() string {
+	return "series/" + s.Series
+}
+
+// Work is the name of a work, which groups the editions of a book: works/{work}.
+type Work struct {
+	Work string
+}
+
+func (w Work) String() string {
+	return "works/" + w.Work
+}
+
+// Operation is the name of a shelf long-running operation:
+// operations/shelves/{shelf} to create it and operations/shelves/{shelf}/delete to delete it.
+type Operation struct {
+	Shelf  string
+	Delete bool
+}
+
+func (o Operation) String() string {
+	if o.Delete {
+		return fmt.Sprintf("operations/shelves/%s/%s", o.Shelf, deleteOperationSuffix)
+	}
+
+	return "operations/shelves/" + o.Shelf
+}
+
+// ValidateID returns a BadRequestError on field if id is not a valid resource ID:
+// a letter or digit followed by letters, digits, '.', '_', '~' or '-', with at most 255 characters.
+func ValidateID(field, id string) error {
+	if violation := idViolation(id); violation != "" {
+		return &errors.BadRequestError{
+			InvalidField: field,
+			Details:      fmt.Sprintf("%s %s", field, violation),
+		}
+	}
+
+	return nil
+}
+
+// idViolation describes why id is invalid, or is empty if id is valid.
+func idViolation(id string) string {
+	if len(id) > MaxIDLength {
+		return fmt.Sprintf("must have at most %d characters", MaxIDLength)
+	}
+
+	if !idRegexp.MatchString(id) {
+		return "must start with a letter or digit followed by letters, digits, '.', '_', '~' or '-'"
+	}
+
+	return ""
+}
+
+// ParseShelf parses a shelf name: shelves/{shelf}.
+func ParseShelf(field, name string) (Shelf, error) {
+	ids, err := parse(field, name, shelfPattern, false)
+	if err != nil {
+		return Shelf{}, err
+	}
+
+	return Shelf{Shelf: ids[0]}, nil
+}
+
+// ParseShelfOrWildcard parses a shelf name that may be "shelves/-", meaning every shelf.
+func ParseShelfOrWildcard(field, name string) (Shelf, error) {
+	ids, err := parse(field, name, shelfPattern, true)
+	if err != nil {
+		return Shelf{}, err
+	}
+
+	return Shelf{Shelf: ids[0]}, nil
+}
+
+// ParseBook parses a book name: shelves/{shelf}/books/{book}.
+func ParseBook(field, name string) (Book, error) {
+	ids, err := parse(field, name, bookPattern, false)
+	if err != nil {
+		return Book{}, err
+	}
+
+	return Book{Shelf: ids[0], Book: ids[1]}, nil
+}
+
+// ParseCopy parses a copy name: shelves/{shelf}/books/{book}/copies/{copy}.
+func ParseCopy(field, name string) (Copy, error) {
+	ids, err := parse(field, name, copyPattern, false)
+	if err != nil {
+		return Copy{}, err
+	}
+
+	return Copy{Shelf: ids[0], Book: ids[1], Copy: ids[2]}, nil
+}
+
+// ParseAuthor parses an author name: authors/{author}.
+func ParseAuthor(field, name string) (Author, error) {
+	ids, err := parse(field, name, authorPattern, false)
+	if err != nil {
+		return Author{}, err
+	}
+
+	return Author{Author: ids[0]}, nil
+}
+
+// ParseSeries parses a series name: series/{series}.
+func ParseSeries(field, name string) (Series, error) {
+	ids, err := parse(field, name, seriesPattern, false)
+	if err != nil {
+		return Series{}, err
+	}
+
+	return Series{Series: ids[0]}, nil
+}
+
+// ParseWork parses a work name: works/{work}.
+func ParseWork(field, name string) (Work, error) {
+	ids, err := parse(field, name, workPattern, false)
+	if err != nil {
+		return Work{}, err
+	}
+
+	return Work{Work: ids[0]}, nil
+}
+
+// ParseOperation parses a shelf operation name: operations/shelves/{shelf} or operations/shelves/{shelf}/delete.
+// The patterns are told apart by their number of segments, as "delete" is a valid shelf ID,
+// e.g. operations/shelves/delete is the operation that created the shelf "delete".
+func ParseOperation(field, name string) (Operation, error) {
+	if strings.Count(name, "/") == strings.Count(deleteOperationPattern, "/") {
+		ids, err := parse(field, name, deleteOperationPattern, false)
+		if err != nil {
+			return Operation{}, err
+		}
+
+		return Operation{Shelf: ids[0], Delete: true}, nil
+	}
+
+	ids, err := parse(field, name, operationPattern, false)
+	if err != nil {
+		return Operation{}, &errors.BadRequestError{
+			InvalidField: field,
+			Details: fmt.Sprintf("%s must be of format '%s' or '%s'",
+				field, operationPattern, deleteOperationPattern),
+		}
+	}
+
+	return Operation{Shelf: ids[0], Delete: false}, nil
+}
+
+// parse returns the IDs of name, which are the segments in braces of pattern.
+// Other segments must be equal in name and pattern.
+func parse(field, name, pattern string, allowWildcard bool) ([]string, error) {
+	nameSegments := strings.Split(name, "/")
+	patternSegments := strings.Split(pattern, "/")
+
+	if len(nameSegments) != len(patternSegments) {
+		return nil, formatError(field, pattern)
+	}
+
+	ids := make([]string, 0, len(patternSegments)/2)
+
+	for i, patternSegment := range patternSegments {
+		if !strings.HasPrefix(patternSegment, "{") {
+			if nameSegments[i] != patternSegment {
+				return nil, formatError(field, pattern)
+			}
+
+			continue
+		}
+
+		id := nameSegments[i]
+		if id == "" {
+			return nil, formatError(field, pattern)
+		}
+
+		if allowWildcard && id == Wildcard {
+			ids = append(ids, id)
+
+			continue
+		}
+
+		if violation := idViolation(id); violation != "" {
+			return nil, &errors.BadRequestError{
+				InvalidField: field,
+				Details:      fmt.Sprintf("%s segment %s %s", field, patternSegment, violation),
+			}
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func formatError(field, pattern string) error {
+	return &errors.BadRequestError{
+		InvalidField: field,
+		Details:      fmt.Sprintf("%s must be of format '%s'", field, pattern),
+	}
+}
@@ -0,0 +1,222 @@
+package resourcename_test
+
+import (
+	stderrors "errors"
+	"strings"
+	"testing"
+
+	"github.com/Henrod/library/domain/errors"
+	"github.com/Henrod/library/domain/resourcename"
+)
+
+// checkBadRequest fails the test if err is not a BadRequestError on field when wantErr, or is not nil otherwise.
+func checkBadRequest(t *testing.T, err error, field string, wantErr bool) {
+	t.Helper()
+
+	if !wantErr {
+		if err != nil {
+			t.Errorf("returned %v, want nil error", err)
+		}
+
+		return
+	}
+
+	var badRequest *errors.BadRequestError
+	if !stderrors.As(err, &badRequest) || badRequest.InvalidField != field {
+		t.Errorf("returned %v, want BadRequestError on %s", err, field)
+	}
+}
+
+func TestValidateID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		id      string
+		wantErr bool
+	}{
+		{name: "letters and digits", id: "shelf1", wantErr: false},
+		{name: "starts with digit", id: "1shelf", wantErr: false},
+		{name: "url safe characters", id: "a.b_c~d-e", wantErr: false},
+		{name: "max length", id: strings.Repeat("a", resourcename.MaxIDLength), wantErr: false},
+		{name: "empty", id: "", wantErr: true},
+		{name: "too long", id: strings.Repeat("a", resourcename.MaxIDLength+1), wantErr: true},
+		{name: "starts with dash", id: "-shelf", wantErr: true},
+		{name: "starts with dot", id: ".shelf", wantErr: true},
+		{name: "wildcard", id: resourcename.Wildcard, wantErr: true},
+		{name: "slash", id: "shelf/1", wantErr: true},
+		{name: "space", id: "shelf 1", wantErr: true},
+		{name: "not ascii", id: "prateleira-ção", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			checkBadRequest(t, resourcename.ValidateID("shelf_id", tt.id), "shelf_id", tt.wantErr)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	// parsers return the name formatted back, so parsed names are compared by their strings.
+	parsers := map[string]func(name string) (string, error){
+		"shelf": func(name string) (string, error) {
+			shelf, err := resourcename.ParseShelf("name", name)
+
+			return shelf.String(), err
+		},
+		"shelf or wildcard": func(name string) (string, error) {
+			shelf, err := resourcename.ParseShelfOrWildcard("name", name)
+
+			return shelf.String(), err
+		},
+		"book": func(name string) (string, error) {
+			book, err := resourcename.ParseBook("name", name)
+
+			return book.String(), err
+		},
+		"copy": func(name string) (string, error) {
+			bookCopy, err := resourcename.ParseCopy("name", name)
+
+			return bookCopy.String(), err
+		},
+		"author": func(name string) (string, error) {
+			author, err := resourcename.ParseAuthor("name", name)
+
+			return author.String(), err
+		},
+		"series": func(name string) (string, error) {
+			series, err := resourcename.ParseSeries("name", name)
+
+			return series.String(), err
+		},
+		"work": func(name string) (string, error) {
+			work, err := resourcename.ParseWork("name", name)
+
+			return work.String(), err
+		},
+	}
+
+	tests := []struct {
+		name     string
+		parser   string
+		resource string
+		wantErr  bool
+	}{
+		{name: "shelf", parser: "shelf", resource: "shelves/shelf1", wantErr: false},
+		{name: "shelf wildcard", parser: "shelf", resource: "shelves/-", wantErr: true},
+		{name: "shelf without id", parser: "shelf", resource: "shelves/", wantErr: true},
+		{name: "shelf of another collection", parser: "shelf", resource: "books/shelf1", wantErr: true},
+		{name: "shelf with invalid id", parser: "shelf", resource: "shelves/_shelf", wantErr: true},
+		{name: "shelf with extra segment", parser: "shelf", resource: "shelves/shelf1/books", wantErr: true},
+		{name: "shelf or wildcard", parser: "shelf or wildcard", resource: "shelves/shelf1", wantErr: false},
+		{name: "wildcard", parser: "shelf or wildcard", resource: "shelves/-", wantErr: false},
+		{name: "book", parser: "book", resource: "shelves/shelf1/books/book1", wantErr: false},
+		{name: "book without shelf", parser: "book", resource: "shelves//books/book1", wantErr: true},
+		{name: "book too long", parser: "book", resource: "shelves/shelf1/books/" + strings.Repeat("b", 256), wantErr: true},
+		{name: "copy", parser: "copy", resource: "shelves/shelf1/books/book1/copies/copy1", wantErr: false},
+		{name: "copy of a shelf", parser: "copy", resource: "shelves/shelf1/copies/copy1", wantErr: true},
+		{name: "author", parser: "author", resource: "authors/henrod", wantErr: false},
+		{name: "author with slash", parser: "author", resource: "authors/hen/rod", wantErr: true},
+		{name: "series", parser: "series", resource: "series/dune", wantErr: false},
+		{name: "series of authors", parser: "series", resource: "authors/dune", wantErr: true},
+		{name: "work", parser: "work", resource: "works/dune", wantErr: false},
+		{name: "empty work", parser: "work", resource: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parsers[tt.parser](tt.resource)
+			checkBadRequest(t, err, "name", tt.wantErr)
+
+			if !tt.wantErr && got != tt.resource {
+				t.Errorf("parse %s(%q) = %q, want the same name", tt.parser, tt.resource, got)
+			}
+		})
+	}
+}
+
+func TestParseOperation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		operation string
+		want      resourcename.Operation
+		wantErr   bool
+	}{
+		{
+			name:      "create operation",
+			operation: "operations/shelves/shelf1",
+			want:      resourcename.Operation{Shelf: "shelf1", Delete: false},
+			wantErr:   false,
+		},
+		{
+			name:      "delete operation",
+			operation: "operations/shelves/shelf1/delete",
+			want:      resourcename.Operation{Shelf: "shelf1", Delete: true},
+			wantErr:   false,
+		},
+		{
+			name:      "create operation of shelf delete",
+			operation: "operations/shelves/delete",
+			want:      resourcename.Operation{Shelf: "delete", Delete: false},
+			wantErr:   false,
+		},
+		{
+			name:      "delete operation of shelf delete",
+			operation: "operations/shelves/delete/delete",
+			want:      resourcename.Operation{Shelf: "delete", Delete: true},
+			wantErr:   false,
+		},
+		{
+			name:      "unknown operation",
+			operation: "operations/shelves/shelf1/update",
+			want:      resourcename.Operation{Shelf: "", Delete: false},
+			wantErr:   true,
+		},
+		{
+			name:      "delete operation without shelf",
+			operation: "operations/shelves//delete",
+			want:      resourcename.Operation{Shelf: "", Delete: false},
+			wantErr:   true,
+		},
+		{
+			name:      "operation of a book",
+			operation: "operations/books/book1",
+			want:      resourcename.Operation{Shelf: "", Delete: false},
+			wantErr:   true,
+		},
+		{
+			name:      "operation with invalid shelf",
+			operation: "operations/shelves/-",
+			want:      resourcename.Operation{Shelf: "", Delete: false},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := resourcename.ParseOperation("name", tt.operation)
+			checkBadRequest(t, err, "name", tt.wantErr)
+
+			if got != tt.want {
+				t.Errorf("ParseOperation(%q) = %+v, want %+v", tt.operation, got, tt.want)
+			}
+
+			if !tt.wantErr && got.String() != tt.operation {
+				t.Errorf("ParseOperation(%q).String() = %q, want the same name", tt.operation, got.String())
+			}
+		})
+	}
+}
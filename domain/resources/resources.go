@@ -0,0 +1,149 @@
+// Package resources has the parts of the use cases shared by the library resources
+// which differ only by their fields and gateway, e.g. authors, series, works and copies.
+package resources
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/Henrod/library/domain/errors"
+	"github.com/Henrod/library/domain/fieldmask"
+	"github.com/Henrod/library/domain/filter"
+	"github.com/Henrod/library/domain/orderby"
+	"github.com/Henrod/library/domain/pagination"
+	"github.com/Henrod/library/domain/resourcename"
+)
+
+// maxDisplayNameLength is the exclusive limit of characters of a display name,
+// which must have less than maxDisplayNameLength characters.
+const maxDisplayNameLength = 255
+
+// outputOnlyFields are the resource fields set by the library, skipped in update masks.
+var outputOnlyFields = []string{"create_time", "update_time"}
+
+// Kind describes a kind of resource to the shared use cases.
+type Kind struct {
+	// Noun names the resource in the request fields and errors, e.g. "author".
+	Noun string
+	// Filterable are the fields accepted in the list filter.
+	Filterable filter.Fields
+	// Sortable are the fields accepted in the list order_by, which are sorted by name on ties.
+	Sortable orderby.Fields
+	// Settable are the fields set by users, on creation and by update masks.
+	Settable []string
+}
+
+// Named returns the kind of a top level resource identified by its name and shown by its display name,
+// e.g. an author, whose settable fields are the display name and extraFields.
+func Named(noun string, extraFields ...string) Kind {
+	return Kind{
+		Noun: noun,
+		Filterable: filter.Fields{
+			"name":         filter.String,
+			"display_name": filter.String,
+			"create_time":  filter.Timestamp,
+			"update_time":  filter.Timestamp,
+		},
+		Sortable: orderby.Fields{
+			"name":         {},
+			"display_name": {},
+			"create_time":  {},
+			"update_time":  {},
+		},
+		Settable: append([]string{"display_name"}, extraFields...),
+	}
+}
+
+// ValidateID validates the ID chosen on creation, sent in the request field <noun>_id.
+func (k Kind) ValidateID(id string) error {
+	return resourcename.ValidateID(k.Noun+"_id", id) //nolint:wrapcheck
+}
+
+// ValidateDisplayName requires the display name, as it is how the resource is presented.
+func (k Kind) ValidateDisplayName(displayName string) error {
+	field := k.Noun + ".display_name"
+
+	if strings.TrimSpace(displayName) == "" {
+		return &errors.BadRequestError{
+			InvalidField: field,
+			Details:      field + " must not be empty",
+		}
+	}
+
+	if utf8.RuneCountInString(displayName) >= maxDisplayNameLength {
+		return &errors.BadRequestError{
+			InvalidField: field,
+			Details:      fmt.Sprintf("%s must have less than %d characters", field, maxDisplayNameLength),
+		}
+	}
+
+	return nil
+}
+
+// UpdateFields returns the fields of updateMask, which must all be settable or output only.
+func (k Kind) UpdateFields(updateMask *fieldmaskpb.FieldMask) ([]string, error) {
+	return fieldmask.Fields(updateMask, k.Noun, k.Settable, outputOnlyFields...) //nolint:wrapcheck
+}
+
+// NotFound returns the NotFoundError of the resource, described by name, e.g. "henrod".
+func (k Kind) NotFound(name string) error {
+	return errors.NotFoundError{
+		Details: fmt.Sprintf("%s %s not found", k.Noun, name),
+	}
+}
+
+// AlreadyExists returns the AlreadyExistsError of the resource, described by name, e.g. "henrod".
+func (k Kind) AlreadyExists(name string) error {
+	return errors.AlreadyExistsError{
+		Details: fmt.Sprintf("%s %s already exists", k.Noun, name),
+	}
+}
+
+// ListQuery is a list request ready for the gateway.
+type ListQuery struct {
+	Expr     filter.Expr
+	Ordering []orderby.Field
+	// Page has one more resource than the requested page, which tells whether there is a next page.
+	Page pagination.Page
+}
+
+// ParseList parses the AIP-160 filter and the AIP-132 order_by of a list request of page.
+// Empty filter matches every resource and empty order_by sorts by name.
+func (k Kind) ParseList(filterExpr, orderBy string, page pagination.Page) (ListQuery, error) {
+	expr, err := filter.Parse(filterExpr, k.Filterable)
+	if err != nil {
+		return ListQuery{}, fmt.Errorf("failed to parse filter: %w", err)
+	}
+
+	ordering, err := orderby.Parse(orderBy, k.Sortable)
+	if err != nil {
+		return ListQuery{}, fmt.Errorf("failed to parse order_by: %w", err)
+	}
+
+	ordering = orderby.WithTiebreakers(ordering, "name")
+	if page.After != nil && len(page.After) != len(ordering) {
+		return ListQuery{}, &errors.BadRequestError{
+			InvalidField: "page_token",
+			Details:      "page_token doesn't match the request order_by",
+		}
+	}
+
+	return ListQuery{
+		Expr:     expr,
+		Ordering: ordering,
+		Page:     pagination.Page{After: page.After, Skip: page.Skip, Size: page.Size + 1},
+	}, nil
+}
+
+// Cursor returns the sort key of a resource in the query ordering, given the values of its sortable fields.
+func (q ListQuery) Cursor(values map[string]interface{}) pagination.Cursor {
+	cursor := make(pagination.Cursor, len(q.Ordering))
+	for i, field := range q.Ordering {
+		cursor[i] = values[field.Name]
+	}
+
+	return cursor
+}
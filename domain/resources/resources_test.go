@@ -0,0 +1,77 @@
+package resources_test
+
+import (
+	stderrors "errors"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/Henrod/library/domain/errors"
+	"github.com/Henrod/library/domain/resources"
+)
+
+func TestUpdateFields(t *testing.T) {
+	t.Parallel()
+
+	kind := resources.Named("series", "volume_count")
+
+	tests := []struct {
+		name    string
+		paths   []string
+		want    []string
+		details string
+	}{
+		{
+			name:    "settable fields",
+			paths:   []string{"volume_count", "display_name"},
+			want:    []string{"display_name", "volume_count"},
+			details: "",
+		},
+		{
+			name:    "output only fields are skipped",
+			paths:   []string{"display_name", "create_time", "update_time"},
+			want:    []string{"display_name"},
+			details: "",
+		},
+		{
+			name:    "name is not settable",
+			paths:   []string{"name", "display_name"},
+			want:    nil,
+			details: `update_mask path "name" is not an updatable series field`,
+		},
+		{
+			name:    "unknown field",
+			paths:   []string{"volumes"},
+			want:    nil,
+			details: `update_mask path "volumes" is not an updatable series field`,
+		},
+		{
+			name:    "only output only fields",
+			paths:   []string{"create_time"},
+			want:    nil,
+			details: "update_mask doesn't have any valid fields to update",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := kind.UpdateFields(&fieldmaskpb.FieldMask{Paths: tt.paths})
+			if tt.details == "" {
+				if err != nil || !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("UpdateFields(%v) = %v, %v, want %v", tt.paths, got, err, tt.want)
+				}
+
+				return
+			}
+
+			var badRequest *errors.BadRequestError
+			if !stderrors.As(err, &badRequest) || badRequest.InvalidField != "update_mask" || badRequest.Details != tt.details {
+				t.Errorf("UpdateFields(%v) returned %v, want update_mask: %q", tt.paths, err, tt.details)
+			}
+		})
+	}
+}
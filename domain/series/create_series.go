@@ -0,0 +1,43 @@
+package series
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Henrod/library/domain/entities"
+)
+
+type CreateSeriesDomain struct {
+	gateway CreateSeriesGateway
+}
+
+func NewCreateSeriesDomain(gateway CreateSeriesGateway) *CreateSeriesDomain {
+	return &CreateSeriesDomain{gateway: gateway}
+}
+
+// CreateSeriesGateway creates the series.
+// If the series already exists, returns nil series and nil error.
+type CreateSeriesGateway interface {
+	CreateSeries(ctx context.Context, series *entities.Series) (*entities.Series, error)
+}
+
+func (c *CreateSeriesDomain) CreateSeries(ctx context.Context, inputSeries *entities.Series) (*entities.Series, error) {
+	if err := seriesKind.ValidateID(inputSeries.Name); err != nil {
+		return nil, err
+	}
+
+	if err := validateSeriesFields(inputSeries, seriesKind.Settable); err != nil {
+		return nil, err
+	}
+
+	series, err := c.gateway.CreateSeries(ctx, inputSeries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create series in gateway: %w", err)
+	}
+
+	if series == nil {
+		return nil, seriesKind.AlreadyExists(inputSeries.Name)
+	}
+
+	return series, nil
+}
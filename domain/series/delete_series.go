@@ -0,0 +1,35 @@
+package series
+
+import (
+	"context"
+	"fmt"
+)
+
+type DeleteSeriesDomain struct {
+	gateway DeleteSeriesGateway
+}
+
+func NewDeleteSeriesDomain(gateway DeleteSeriesGateway) *DeleteSeriesDomain {
+	return &DeleteSeriesDomain{gateway: gateway}
+}
+
+// DeleteSeriesGateway removes the series, returning false if it is not found.
+// If any book is part of the series, including deleted books not purged yet, returns FailedPreconditionError.
+type DeleteSeriesGateway interface {
+	DeleteSeries(ctx context.Context, seriesName string) (bool, error)
+}
+
+// DeleteSeries removes the series from the library.
+// Its books must be removed from the series first, so no book refers to a missing series.
+func (d *DeleteSeriesDomain) DeleteSeries(ctx context.Context, seriesName string) error {
+	deleted, err := d.gateway.DeleteSeries(ctx, seriesName)
+	if err != nil {
+		return fmt.Errorf("failed to delete series in gateway: %w", err)
+	}
+
+	if !deleted {
+		return seriesKind.NotFound(seriesName)
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package series
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Henrod/library/domain/entities"
+)
+
+type GetSeriesDomain struct {
+	gateway GetSeriesGateway
+}
+
+func NewGetSeriesDomain(gateway GetSeriesGateway) *GetSeriesDomain {
+	return &GetSeriesDomain{gateway: gateway}
+}
+
+// GetSeriesGateway returns the series of name.
+// If the series is not found, returns nil series and nil error.
+type GetSeriesGateway interface {
+	GetSeries(ctx context.Context, seriesName string) (*entities.Series, error)
+}
+
+func (g *GetSeriesDomain) GetSeries(ctx context.Context, seriesName string) (*entities.Series, error) {
+	series, err := g.gateway.GetSeries(ctx, seriesName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series from gateway: %w", err)
+	}
+
+	if series == nil {
+		return nil, seriesKind.NotFound(seriesName)
+	}
+
+	return series, nil
+}
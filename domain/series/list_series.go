@@ -0,0 +1,64 @@
+package series
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/filter"
+	"github.com/Henrod/library/domain/orderby"
+	"github.com/Henrod/library/domain/pagination"
+)
+
+type ListSeriesDomain struct {
+	gateway ListSeriesGateway
+}
+
+func NewListSeriesDomain(gateway ListSeriesGateway) *ListSeriesDomain {
+	return &ListSeriesDomain{gateway: gateway}
+}
+
+// ListSeriesGateway lists the series of page sorted by ordering.
+type ListSeriesGateway interface {
+	ListSeries(
+		ctx context.Context,
+		expr filter.Expr,
+		ordering []orderby.Field,
+		page pagination.Page,
+	) ([]*entities.Series, error)
+}
+
+// List returns the series matching the AIP-160 filter sorted by the AIP-132 order_by.
+// Empty filter matches every series and empty order_by sorts by series name.
+//
+// The page starts right after its cursor, which is nil for the first page.
+// The returned cursor points to the last series of the page and is nil when there are no more pages.
+func (l *ListSeriesDomain) List(
+	ctx context.Context,
+	filterExpr, orderBy string,
+	page pagination.Page,
+) (series []*entities.Series, next pagination.Cursor, err error) {
+	query, err := seriesKind.ParseList(filterExpr, orderBy, page)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	series, err = l.gateway.ListSeries(ctx, query.Expr, query.Ordering, query.Page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list series in gateway: %w", err)
+	}
+
+	if len(series) <= page.Size {
+		return series, nil, nil
+	}
+
+	series = series[:page.Size]
+	last := series[len(series)-1]
+
+	return series, query.Cursor(map[string]interface{}{
+		"name":         last.Name,
+		"display_name": last.DisplayName,
+		"create_time":  last.CreateTime,
+		"update_time":  last.UpdateTime,
+	}), nil
+}
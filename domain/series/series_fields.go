@@ -0,0 +1,36 @@
+package series
+
+import (
+	"fmt"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/errors"
+	"github.com/Henrod/library/domain/resources"
+)
+
+// maxVolumeCount is the maximum number of volumes of a series.
+const maxVolumeCount = 10000
+
+// seriesKind describes the series to the use cases shared by the resources.
+var seriesKind = resources.Named("series", "volume_count")
+
+// validateSeriesFields validates the fields of series.
+func validateSeriesFields(series *entities.Series, fields []string) error {
+	for _, field := range fields {
+		switch field {
+		case "display_name":
+			if err := seriesKind.ValidateDisplayName(series.DisplayName); err != nil {
+				return err
+			}
+		case "volume_count":
+			if series.VolumeCount < 0 || series.VolumeCount > maxVolumeCount {
+				return &errors.BadRequestError{
+					InvalidField: "series.volume_count",
+					Details:      fmt.Sprintf("series.volume_count must be between 0 and %d", maxVolumeCount),
+				}
+			}
+		}
+	}
+
+	return nil
+}
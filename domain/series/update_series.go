@@ -0,0 +1,50 @@
+package series
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/Henrod/library/domain/entities"
+)
+
+type UpdateSeriesDomain struct {
+	gateway UpdateSeriesGateway
+}
+
+func NewUpdateSeriesDomain(gateway UpdateSeriesGateway) *UpdateSeriesDomain {
+	return &UpdateSeriesDomain{gateway: gateway}
+}
+
+// UpdateSeriesGateway updates only the series fields in fields.
+// If the series is not found, returns nil series and nil error.
+type UpdateSeriesGateway interface {
+	UpdateSeries(ctx context.Context, series *entities.Series, fields []string) (*entities.Series, error)
+}
+
+func (u *UpdateSeriesDomain) UpdateSeries(
+	ctx context.Context,
+	inputSeries *entities.Series,
+	updateMask *fieldmaskpb.FieldMask,
+) (*entities.Series, error) {
+	fields, err := seriesKind.UpdateFields(updateMask)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateSeriesFields(inputSeries, fields); err != nil {
+		return nil, err
+	}
+
+	series, err := u.gateway.UpdateSeries(ctx, inputSeries, fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update series in gateway: %w", err)
+	}
+
+	if series == nil {
+		return nil, seriesKind.NotFound(inputSeries.Name)
+	}
+
+	return series, nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/Henrod/library/domain/entities"
 	"github.com/Henrod/library/domain/errors"
+	"github.com/Henrod/library/domain/resourcename"
 	"go.uber.org/zap"
 )
 
@@ -64,6 +65,14 @@ func (c *CreateShelfDomain) StartCreateShelfOperation(
 	_ context.Context,
 	inputShelf *entities.Shelf,
 ) (*entities.Operation, error) {
+	if err := resourcename.ValidateID("shelf.name", inputShelf.Name); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	if err := validateShelfFields(inputShelf, shelfFields); err != nil {
+		return nil, err
+	}
+
 	if _, ok := c.pendingShelves[inputShelf.Name]; ok {
 		return nil, errors.AlreadyExistsError{
 			Details: fmt.Sprintf("create shelf %s operation already exists", inputShelf.Name),
@@ -0,0 +1,233 @@
+package shelves
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/errors"
+	"github.com/Henrod/library/domain/filter"
+	"go.uber.org/zap"
+)
+
+type DeleteShelfDomain struct {
+	gateway DeleteShelfGateway
+	log     *zap.SugaredLogger
+
+	// This is not scalable, but works for studying purposes.
+	pendingShelves map[string]*shelfDeletionStatus
+	mutex          sync.RWMutex
+}
+
+func NewDeleteShelfDomain(
+	log *zap.SugaredLogger,
+	gateway DeleteShelfGateway,
+) *DeleteShelfDomain {
+	domain := &DeleteShelfDomain{
+		log:            log,
+		gateway:        gateway,
+		pendingShelves: make(map[string]*shelfDeletionStatus),
+		mutex:          sync.RWMutex{},
+	}
+
+	go domain.cleanUp()
+
+	return domain
+}
+
+type DeleteShelfGateway interface {
+	GetShelf(ctx context.Context, shelfName string) (*entities.Shelf, error)
+	CountShelfBooks(ctx context.Context, shelfName string, expr filter.Expr, showDeleted bool) (int, error)
+	DeleteShelfBooks(ctx context.Context, shelfName string, limit int) (int, error)
+	DeleteShelf(ctx context.Context, shelfName string) (bool, error)
+}
+
+type shelfDeletionStatus struct {
+	stage        int
+	totalBooks   int
+	deletedBooks int
+	err          error
+	finished     bool
+	finishTime   time.Time
+}
+
+var shelfDeletionStages = []string{
+	"REMOVING_BOOKS",
+	"UNINSTALLING_SHELF",
+	"FINISHED_SHELF_DELETION",
+}
+
+const (
+	stageRemovingBooks = iota
+	stageUninstallingShelf
+	stageFinishedShelfDeletion
+)
+
+const deleteBooksBatchSize = 100
+
+// StartDeleteShelfOperation starts a long-running operation to delete a shelf.
+// If the shelf still has books not deleted, they are deleted in batches only when force is true;
+// otherwise, a FailedPreconditionError is returned and nothing is deleted.
+// After starting it, retrieve the deletion status in the Operation API: `GET /operations/shelves/{shelf_name}/delete`
+// If the operation fails, its reason is retrievable until expiration time.
+func (d *DeleteShelfDomain) StartDeleteShelfOperation(
+	ctx context.Context,
+	shelfName string,
+	force bool,
+) (*entities.Operation, error) {
+	shelf, err := d.gateway.GetShelf(ctx, shelfName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shelf from gateway: %w", err)
+	}
+
+	if shelf == nil {
+		return nil, errors.NotFoundError{
+			Details: fmt.Sprintf("shelf %s not found", shelfName),
+		}
+	}
+
+	activeBooks, err := d.gateway.CountShelfBooks(ctx, shelfName, nil, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count shelf books in gateway: %w", err)
+	}
+
+	if activeBooks > 0 && !force {
+		return nil, errors.FailedPreconditionError{
+			Details: fmt.Sprintf("shelf %s has %d books, set force to delete them", shelfName, activeBooks),
+		}
+	}
+
+	// Deleted books don't prevent the shelf deletion, but they are removed with it.
+	totalBooks, err := d.gateway.CountShelfBooks(ctx, shelfName, nil, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count shelf books in gateway: %w", err)
+	}
+
+	d.mutex.Lock()
+	if _, ok := d.pendingShelves[shelfName]; ok {
+		d.mutex.Unlock()
+
+		return nil, errors.AlreadyExistsError{
+			Details: fmt.Sprintf("delete shelf %s operation already exists", shelfName),
+		}
+	}
+
+	d.pendingShelves[shelfName] = &shelfDeletionStatus{totalBooks: totalBooks} //nolint:exhaustivestruct
+	d.mutex.Unlock()
+
+	go d.deleteShelf(context.Background(), shelfName)
+
+	operation, _ := d.GetOperation(shelfName)
+
+	return operation, nil
+}
+
+func (d *DeleteShelfDomain) cleanUp() {
+	for range time.NewTicker(time.Minute).C {
+		now := time.Now()
+
+		d.mutex.Lock()
+		for shelfName, status := range d.pendingShelves {
+			isExpired := now.After(status.finishTime.Add(failedStageExpirationTime))
+			if status.finished && isExpired {
+				delete(d.pendingShelves, shelfName)
+			}
+		}
+		d.mutex.Unlock()
+	}
+}
+
+func (d *DeleteShelfDomain) deleteShelf(ctx context.Context, shelfName string) {
+	log := d.log.With(zap.String("shelf", shelfName))
+	log.Info("started deleting shelf")
+
+	err := d.executeStages(ctx, shelfName)
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	status := d.pendingShelves[shelfName]
+	status.err = err
+	status.finishTime = time.Now()
+	status.finished = true
+
+	if err != nil {
+		log.With(zap.Error(err)).Error("failure deleting shelf")
+
+		return
+	}
+
+	status.stage = stageFinishedShelfDeletion
+	log.Info("finished deleting shelf")
+}
+
+func (d *DeleteShelfDomain) executeStages(ctx context.Context, shelfName string) error {
+	for {
+		deleted, err := d.gateway.DeleteShelfBooks(ctx, shelfName, deleteBooksBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to delete shelf books in gateway: %w", err)
+		}
+
+		if deleted == 0 {
+			break
+		}
+
+		d.mutex.Lock()
+		d.pendingShelves[shelfName].deletedBooks += deleted
+		d.mutex.Unlock()
+	}
+
+	d.mutex.Lock()
+	d.pendingShelves[shelfName].stage = stageUninstallingShelf
+	d.mutex.Unlock()
+
+	deleted, err := d.gateway.DeleteShelf(ctx, shelfName)
+	if err != nil {
+		return fmt.Errorf("failed to delete shelf in gateway: %w", err)
+	}
+
+	if !deleted {
+		return errors.NotFoundError{
+			Details: fmt.Sprintf("shelf %s not found", shelfName),
+		}
+	}
+
+	return nil
+}
+
+// GetOperation returns the deletion progress of shelfName.
+// Percentage counts each deleted book and the shelf itself as one step.
+func (d *DeleteShelfDomain) GetOperation(shelfName string) (*entities.Operation, error) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	status, ok := d.pendingShelves[shelfName]
+	if !ok {
+		return nil, errors.NotFoundError{
+			Details: fmt.Sprintf("operation for shelf not found: %s", shelfName),
+		}
+	}
+
+	totalSteps := status.totalBooks + 1
+	executedSteps := status.deletedBooks
+	if executedSteps > status.totalBooks {
+		executedSteps = status.totalBooks
+	}
+
+	if status.stage == stageFinishedShelfDeletion {
+		executedSteps = totalSteps
+	}
+
+	return &entities.Operation{
+		Name:       d.GetOperationName(shelfName),
+		Stage:      shelfDeletionStages[status.stage],
+		Percentage: executedSteps * 100 / totalSteps,
+		Error:      status.err,
+	}, nil
+}
+
+func (d *DeleteShelfDomain) GetOperationName(shelfName string) string {
+	return fmt.Sprintf("operations/shelves/%s/delete", shelfName)
+}
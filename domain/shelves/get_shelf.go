@@ -38,8 +38,10 @@ func (g *GetShelfDomain) GetShelf(ctx context.Context, shelfName string) (*entit
 	}
 
 	return &entities.Shelf{
-		Name:       shelf.Name,
-		CreateTime: shelf.CreateTime,
-		UpdateTime: shelf.UpdateTime,
+		Name:        shelf.Name,
+		DisplayName: shelf.DisplayName,
+		Labels:      shelf.Labels,
+		CreateTime:  shelf.CreateTime,
+		UpdateTime:  shelf.UpdateTime,
 	}, nil
 }
@@ -0,0 +1,112 @@
+package shelves
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/errors"
+	"github.com/Henrod/library/domain/filter"
+	"github.com/Henrod/library/domain/orderby"
+	"github.com/Henrod/library/domain/pagination"
+)
+
+type ListShelvesDomain struct {
+	gateway ListShelvesGateway
+}
+
+// filterableFields are the shelf fields accepted in the list filter.
+var filterableFields = filter.Fields{
+	"name":         filter.String,
+	"display_name": filter.String,
+	"create_time":  filter.Timestamp,
+	"update_time":  filter.Timestamp,
+	"labels":       filter.Map,
+}
+
+// sortableFields are the shelf fields accepted in the list order_by.
+var sortableFields = orderby.Fields{
+	"name":         {},
+	"display_name": {},
+	"create_time":  {},
+	"update_time":  {},
+}
+
+func NewListShelvesDomain(gateway ListShelvesGateway) *ListShelvesDomain {
+	return &ListShelvesDomain{gateway: gateway}
+}
+
+// ListShelvesGateway lists the shelves of page sorted by ordering.
+type ListShelvesGateway interface {
+	ListShelves(
+		ctx context.Context,
+		expr filter.Expr,
+		ordering []orderby.Field,
+		page pagination.Page,
+	) ([]*entities.Shelf, error)
+}
+
+// List returns the shelves matching the AIP-160 filter sorted by the AIP-132 order_by.
+// Empty filter matches every shelf and empty order_by sorts by shelf name.
+//
+// The page starts right after its cursor, which is nil for the first page, and skipped shelves.
+// The returned cursor points to the last shelf of the page and is nil when there are no more pages.
+func (l *ListShelvesDomain) List(
+	ctx context.Context,
+	filterExpr, orderBy string,
+	page pagination.Page,
+) (shelves []*entities.Shelf, next pagination.Cursor, err error) {
+	expr, err := filter.Parse(filterExpr, filterableFields)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse filter: %w", err)
+	}
+
+	ordering, err := orderby.Parse(orderBy, sortableFields)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse order_by: %w", err)
+	}
+
+	ordering = orderby.WithTiebreakers(ordering, "name")
+	if page.After != nil && len(page.After) != len(ordering) {
+		return nil, nil, &errors.BadRequestError{
+			InvalidField: "page_token",
+			Details:      "page_token doesn't match the request order_by",
+		}
+	}
+
+	// One more shelf than the page size tells whether there is a next page.
+	gatewayPage := pagination.Page{After: page.After, Skip: page.Skip, Size: page.Size + 1}
+
+	shelves, err = l.gateway.ListShelves(ctx, expr, ordering, gatewayPage)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list shelves in gateway: %w", err)
+	}
+
+	if len(shelves) <= page.Size {
+		return shelves, nil, nil
+	}
+
+	shelves = shelves[:page.Size]
+
+	return shelves, shelfCursor(shelves[len(shelves)-1], ordering), nil
+}
+
+// shelfCursor returns the sort key of shelf in ordering.
+func shelfCursor(shelf *entities.Shelf, ordering []orderby.Field) pagination.Cursor {
+	cursor := make(pagination.Cursor, len(ordering))
+
+	for i, field := range ordering {
+		switch field.Name {
+		case "name":
+			cursor[i] = shelf.Name
+		case "display_name":
+			cursor[i] = shelf.DisplayName
+		case "create_time":
+			cursor[i] = shelf.CreateTime
+		case "update_time":
+			cursor[i] = shelf.UpdateTime
+		}
+	}
+
+	return cursor
+}
@@ -0,0 +1,38 @@
+package shelves
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/errors"
+	"github.com/Henrod/library/domain/labels"
+)
+
+// maxDisplayNameLength is the exclusive limit of characters of the shelf display name,
+// which must have less than maxDisplayNameLength characters.
+const maxDisplayNameLength = 255
+
+// shelfFields are the user settable shelf fields.
+var shelfFields = []string{"display_name", "labels"}
+
+// validateShelfFields validates the fields of shelf.
+func validateShelfFields(shelf *entities.Shelf, fields []string) error {
+	for _, field := range fields {
+		switch field {
+		case "display_name":
+			if utf8.RuneCountInString(shelf.DisplayName) >= maxDisplayNameLength {
+				return &errors.BadRequestError{
+					InvalidField: "shelf.display_name",
+					Details:      fmt.Sprintf("shelf.display_name must have less than %d characters", maxDisplayNameLength),
+				}
+			}
+		case "labels":
+			if err := labels.Validate("shelf.labels", shelf.Labels); err != nil {
+				return err //nolint:wrapcheck
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package shelves
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/errors"
+	"github.com/Henrod/library/domain/fieldmask"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+type UpdateShelfDomain struct {
+	gateway UpdateShelfGateway
+}
+
+// outputOnlyFields are skipped in the update mask, as they are set by the server.
+var outputOnlyFields = []string{"create_time", "update_time"}
+
+func NewUpdateShelfDomain(gateway UpdateShelfGateway) *UpdateShelfDomain {
+	return &UpdateShelfDomain{gateway: gateway}
+}
+
+type UpdateShelfGateway interface {
+	UpdateShelf(ctx context.Context, shelf *entities.Shelf, fields []string) (*entities.Shelf, error)
+}
+
+func (u *UpdateShelfDomain) UpdateShelf(
+	ctx context.Context,
+	inputShelf *entities.Shelf,
+	updateMask *fieldmaskpb.FieldMask,
+) (*entities.Shelf, error) {
+	fields, err := fieldmask.Fields(updateMask, "shelf", shelfFields, outputOnlyFields...)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	if err := validateShelfFields(inputShelf, fields); err != nil {
+		return nil, err
+	}
+
+	shelf, err := u.gateway.UpdateShelf(ctx, inputShelf, fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update shelf in gateway: %w", err)
+	}
+
+	if shelf == nil {
+		return nil, errors.NotFoundError{
+			Details: fmt.Sprintf("shelf %s not found", inputShelf.Name),
+		}
+	}
+
+	return shelf, nil
+}
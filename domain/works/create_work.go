@@ -0,0 +1,43 @@
+package works
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Henrod/library/domain/entities"
+)
+
+type CreateWorkDomain struct {
+	gateway CreateWorkGateway
+}
+
+func NewCreateWorkDomain(gateway CreateWorkGateway) *CreateWorkDomain {
+	return &CreateWorkDomain{gateway: gateway}
+}
+
+// CreateWorkGateway creates the work.
+// If the work already exists, returns nil work and nil error.
+type CreateWorkGateway interface {
+	CreateWork(ctx context.Context, work *entities.Work) (*entities.Work, error)
+}
+
+func (c *CreateWorkDomain) CreateWork(ctx context.Context, inputWork *entities.Work) (*entities.Work, error) {
+	if err := workKind.ValidateID(inputWork.Name); err != nil {
+		return nil, err
+	}
+
+	if err := workKind.ValidateDisplayName(inputWork.DisplayName); err != nil {
+		return nil, err
+	}
+
+	work, err := c.gateway.CreateWork(ctx, inputWork)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create work in gateway: %w", err)
+	}
+
+	if work == nil {
+		return nil, workKind.AlreadyExists(inputWork.Name)
+	}
+
+	return work, nil
+}
@@ -0,0 +1,35 @@
+package works
+
+import (
+	"context"
+	"fmt"
+)
+
+type DeleteWorkDomain struct {
+	gateway DeleteWorkGateway
+}
+
+func NewDeleteWorkDomain(gateway DeleteWorkGateway) *DeleteWorkDomain {
+	return &DeleteWorkDomain{gateway: gateway}
+}
+
+// DeleteWorkGateway removes the work, returning false if it is not found.
+// If any book is linked to the work, including deleted books not purged yet, returns FailedPreconditionError.
+type DeleteWorkGateway interface {
+	DeleteWork(ctx context.Context, workName string) (bool, error)
+}
+
+// DeleteWork removes the work from the library.
+// Its books must be linked to another work or unlinked first, so no book refers to a missing work.
+func (d *DeleteWorkDomain) DeleteWork(ctx context.Context, workName string) error {
+	deleted, err := d.gateway.DeleteWork(ctx, workName)
+	if err != nil {
+		return fmt.Errorf("failed to delete work in gateway: %w", err)
+	}
+
+	if !deleted {
+		return workKind.NotFound(workName)
+	}
+
+	return nil
+}
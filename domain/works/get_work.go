@@ -0,0 +1,35 @@
+package works
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Henrod/library/domain/entities"
+)
+
+type GetWorkDomain struct {
+	gateway GetWorkGateway
+}
+
+func NewGetWorkDomain(gateway GetWorkGateway) *GetWorkDomain {
+	return &GetWorkDomain{gateway: gateway}
+}
+
+// GetWorkGateway returns the work of name.
+// If the work is not found, returns nil work and nil error.
+type GetWorkGateway interface {
+	GetWork(ctx context.Context, workName string) (*entities.Work, error)
+}
+
+func (g *GetWorkDomain) GetWork(ctx context.Context, workName string) (*entities.Work, error) {
+	work, err := g.gateway.GetWork(ctx, workName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work from gateway: %w", err)
+	}
+
+	if work == nil {
+		return nil, workKind.NotFound(workName)
+	}
+
+	return work, nil
+}
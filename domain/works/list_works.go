@@ -0,0 +1,64 @@
+package works
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/filter"
+	"github.com/Henrod/library/domain/orderby"
+	"github.com/Henrod/library/domain/pagination"
+)
+
+type ListWorksDomain struct {
+	gateway ListWorksGateway
+}
+
+func NewListWorksDomain(gateway ListWorksGateway) *ListWorksDomain {
+	return &ListWorksDomain{gateway: gateway}
+}
+
+// ListWorksGateway lists the works of page sorted by ordering.
+type ListWorksGateway interface {
+	ListWorks(
+		ctx context.Context,
+		expr filter.Expr,
+		ordering []orderby.Field,
+		page pagination.Page,
+	) ([]*entities.Work, error)
+}
+
+// List returns the works matching the AIP-160 filter sorted by the AIP-132 order_by.
+// Empty filter matches every work and empty order_by sorts by work name.
+//
+// The page starts right after its cursor, which is nil for the first page.
+// The returned cursor points to the last work of the page and is nil when there are no more pages.
+func (l *ListWorksDomain) List(
+	ctx context.Context,
+	filterExpr, orderBy string,
+	page pagination.Page,
+) (works []*entities.Work, next pagination.Cursor, err error) {
+	query, err := workKind.ParseList(filterExpr, orderBy, page)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	works, err = l.gateway.ListWorks(ctx, query.Expr, query.Ordering, query.Page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list works in gateway: %w", err)
+	}
+
+	if len(works) <= page.Size {
+		return works, nil, nil
+	}
+
+	works = works[:page.Size]
+	last := works[len(works)-1]
+
+	return works, query.Cursor(map[string]interface{}{
+		"name":         last.Name,
+		"display_name": last.DisplayName,
+		"create_time":  last.CreateTime,
+		"update_time":  last.UpdateTime,
+	}), nil
+}
@@ -0,0 +1,50 @@
+package works
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/Henrod/library/domain/entities"
+)
+
+type UpdateWorkDomain struct {
+	gateway UpdateWorkGateway
+}
+
+func NewUpdateWorkDomain(gateway UpdateWorkGateway) *UpdateWorkDomain {
+	return &UpdateWorkDomain{gateway: gateway}
+}
+
+// UpdateWorkGateway updates only the work fields in fields.
+// If the work is not found, returns nil work and nil error.
+type UpdateWorkGateway interface {
+	UpdateWork(ctx context.Context, work *entities.Work, fields []string) (*entities.Work, error)
+}
+
+func (u *UpdateWorkDomain) UpdateWork(
+	ctx context.Context,
+	inputWork *entities.Work,
+	updateMask *fieldmaskpb.FieldMask,
+) (*entities.Work, error) {
+	fields, err := workKind.UpdateFields(updateMask)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := workKind.ValidateDisplayName(inputWork.DisplayName); err != nil {
+		return nil, err
+	}
+
+	work, err := u.gateway.UpdateWork(ctx, inputWork, fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update work in gateway: %w", err)
+	}
+
+	if work == nil {
+		return nil, workKind.NotFound(inputWork.Name)
+	}
+
+	return work, nil
+}
@@ -0,0 +1,6 @@
+package works
+
+import "github.com/Henrod/library/domain/resources"
+
+// workKind describes the works to the use cases shared by the resources.
+var workKind = resources.Named("work")
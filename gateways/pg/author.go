@@ -0,0 +1,154 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+
+	"github.com/Henrod/library/domain/entities"
+	domainErrors "github.com/Henrod/library/domain/errors"
+	"github.com/Henrod/library/domain/filter"
+	"github.com/Henrod/library/domain/orderby"
+	"github.com/Henrod/library/domain/pagination"
+)
+
+// bookAuthorConstraint is the foreign key from the books to their authors.
+const bookAuthorConstraint = "fk_author"
+
+// authorTable has the author queries shared by the resources.
+var authorTable = resourceTable{
+	noun:       "author",
+	primaryKey: "authors_pkey",
+	columns:    namedColumns("author"),
+	bookColumn: "book.author_name",
+}
+
+type Author struct {
+	Name        string `pg:",pk"`
+	DisplayName string
+	CreateTime  time.Time
+	UpdateTime  time.Time
+}
+
+func (a *Author) toEntity() *entities.Author {
+	return &entities.Author{
+		Name:        a.Name,
+		DisplayName: a.DisplayName,
+		CreateTime:  a.CreateTime,
+		UpdateTime:  a.UpdateTime,
+	}
+}
+
+// isAuthorViolation tells whether err is caused by a book linked to an author that doesn't exist,
+// or by removing an author that still has books.
+func isAuthorViolation(err error) bool {
+	var pgErr pg.Error
+
+	return errors.As(err, &pgErr) &&
+		pgErr.Field('C') == pgForeignKeyViolation &&
+		pgErr.Field('n') == bookAuthorConstraint
+}
+
+func (g *Gateway) ListAuthors(
+	ctx context.Context,
+	expr filter.Expr,
+	ordering []orderby.Field,
+	page pagination.Page,
+) ([]*entities.Author, error) {
+	var authors []*Author
+
+	err := authorTable.list(g.db.ModelContext(ctx, &authors), expr, ordering, page)
+	if err != nil {
+		return nil, err
+	}
+
+	eAuthors := make([]*entities.Author, len(authors))
+	for i, author := range authors {
+		eAuthors[i] = author.toEntity()
+	}
+
+	return eAuthors, nil
+}
+
+// CreateAuthor inserts the author.
+// If the author already exists, returns nil author and nil error.
+func (g *Gateway) CreateAuthor(ctx context.Context, eAuthor *entities.Author) (*entities.Author, error) {
+	now := time.Now()
+
+	author := &Author{
+		Name:        eAuthor.Name,
+		DisplayName: eAuthor.DisplayName,
+		CreateTime:  now,
+		UpdateTime:  now,
+	}
+
+	created, err := authorTable.insert(g.db.ModelContext(ctx, author))
+	if !created {
+		return nil, err
+	}
+
+	return author.toEntity(), nil
+}
+
+// GetAuthor returns the author of name.
+// If the author is not found, returns nil author and nil error.
+func (g *Gateway) GetAuthor(ctx context.Context, authorName string) (*entities.Author, error) {
+	author := &Author{Name: authorName} //nolint:exhaustivestruct
+
+	found, err := authorTable.get(g.db.ModelContext(ctx, author).WherePK())
+	if !found {
+		return nil, err
+	}
+
+	return author.toEntity(), nil
+}
+
+// UpdateAuthor updates only the author columns in fields.
+// If the author is not found, returns nil author and nil error.
+func (g *Gateway) UpdateAuthor(
+	ctx context.Context,
+	eAuthor *entities.Author,
+	fields []string,
+) (*entities.Author, error) {
+	author := &Author{
+		Name:        eAuthor.Name,
+		DisplayName: eAuthor.DisplayName,
+		CreateTime:  time.Time{},
+		UpdateTime:  time.Now(),
+	}
+
+	found, err := authorTable.update(g.db.ModelContext(ctx, author).WherePK(), fields)
+	if !found {
+		return nil, err
+	}
+
+	return author.toEntity(), nil
+}
+
+// DeleteAuthor removes the author, returning false if it is not found.
+// If any book is still linked to the author, returns FailedPreconditionError.
+func (g *Gateway) DeleteAuthor(ctx context.Context, authorName string) (bool, error) {
+	author := &Author{Name: authorName} //nolint:exhaustivestruct
+
+	deleted, err := authorTable.delete(g.db.ModelContext(ctx, author).WherePK())
+	if isAuthorViolation(err) {
+		return false, domainErrors.FailedPreconditionError{
+			Details: fmt.Sprintf("author %s has books, link them to another author or unlink them first", authorName),
+		}
+	}
+
+	return deleted, err
+}
+
+// ListAuthorBooks returns the page of not deleted books linked to the author, from every shelf.
+func (g *Gateway) ListAuthorBooks(
+	ctx context.Context,
+	authorName string,
+	ordering []orderby.Field,
+	page pagination.Page,
+) ([]*entities.Book, error) {
+	return authorTable.listBooks(ctx, g.db, authorName, ordering, page)
+}
@@ -7,39 +7,119 @@ import (
 	"time"
 
 	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
 
+	domainBooks "github.com/Henrod/library/domain/books"
 	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/filter"
+	"github.com/Henrod/library/domain/orderby"
+	"github.com/Henrod/library/domain/pagination"
 )
 
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
+
+// bookShelfConstraint is the foreign key of the book shelf.
+const bookShelfConstraint = "fk_shelf"
+
 type Book struct {
-	ShelfName  string `pg:",pk"`
-	Shelf      *Shelf `pg:"rel:has-one"`
-	Name       string `pg:",pk"`
-	Author     string
-	CreateTime time.Time
-	UpdateTime time.Time
+	// The search_vector column is generated by Postgres and only used in search queries.
+	tableName struct{} `pg:",discard_unknown_columns"` //nolint:structcheck,unused
+
+	ShelfName string `pg:",pk"`
+	Shelf     *Shelf `pg:"rel:has-one"`
+	Name      string `pg:",pk"`
+	// Author is the first author contributor, kept in a column to search, sort and filter books by it.
+	Author          string
+	Contributors    []*BookContributor `pg:"rel:has-many"`
+	AuthorName      string
+	SeriesName      string
+	VolumeNumber    int
+	WorkName        string
+	Title           string
+	ISBN            string `pg:"isbn"`
+	Publisher       string
+	PublicationYear int
+	Language        string
+	PageCount       int
+	Labels          map[string]string
+	Description     string
+	CreateTime      time.Time
+	UpdateTime      time.Time
+	DeleteTime      time.Time
+	PurgeTime       time.Time
+	// Version is incremented on every change, so concurrent changes can be detected.
+	Version int64
+}
+
+// whereNotDeleted hides the soft deleted books from query, unless showDeleted is true.
+func whereNotDeleted(query *orm.Query, showDeleted bool) *orm.Query {
+	if showDeleted {
+		return query
+	}
+
+	return query.Where("book.delete_time IS NULL")
 }
 
 func (b *Book) toEntity() *entities.Book {
+	shelf := b.Shelf
+	if shelf == nil {
+		// Relation was not selected, only the shelf name is known.
+		shelf = &Shelf{Name: b.ShelfName} //nolint:exhaustivestruct
+	}
+
+	contributors := make([]*entities.Contributor, len(b.Contributors))
+	for i, contributor := range b.Contributors {
+		contributors[i] = contributor.toEntity()
+	}
+
 	return &entities.Book{
-		Name:       b.Name,
-		Author:     b.Author,
-		CreateTime: b.CreateTime,
-		UpdateTime: b.UpdateTime,
-		Shelf:      b.Shelf.toEntity(),
+		Name:            b.Name,
+		Contributors:    contributors,
+		AuthorName:      b.AuthorName,
+		SeriesName:      b.SeriesName,
+		VolumeNumber:    b.VolumeNumber,
+		WorkName:        b.WorkName,
+		Title:           b.Title,
+		ISBN:            b.ISBN,
+		Publisher:       b.Publisher,
+		PublicationYear: b.PublicationYear,
+		Language:        b.Language,
+		PageCount:       b.PageCount,
+		Labels:          b.Labels,
+		Description:     b.Description,
+		CreateTime:      b.CreateTime,
+		UpdateTime:      b.UpdateTime,
+		DeleteTime:      b.DeleteTime,
+		PurgeTime:       b.PurgeTime,
+		Version:         b.Version,
+		Shelf:           shelf.toEntity(),
 	}
 }
 
 func (g *Gateway) ListBooks(
 	ctx context.Context,
-	pageSize, pageOffset int,
+	expr filter.Expr,
+	showDeleted bool,
+	ordering []orderby.Field,
+	page pagination.Page,
 ) ([]*entities.Book, error) {
 	var books []*Book
-	err := g.db.ModelContext(ctx, &books).
-		Relation("Shelf").
-		Limit(pageSize).
-		Offset(pageOffset).
-		Select()
+	query := whereNotDeleted(g.db.ModelContext(ctx, &books).Relation("Shelf"), showDeleted).
+		Relation("Contributors", orderContributors)
+	query, err := whereFilter(query, expr, bookFilterColumns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter books: %w", err)
+	}
+
+	query, err = paginate(query, ordering, page, bookOrderColumns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to paginate books: %w", err)
+	}
+
+	err = query.Select()
 	if err != nil {
 		return nil, fmt.Errorf("failed to select books in postgres: %w", err)
 	}
@@ -55,14 +135,26 @@ func (g *Gateway) ListBooks(
 func (g *Gateway) ListShelfBooks(
 	ctx context.Context,
 	shelfName string,
-	pageSize, pageOffset int,
+	expr filter.Expr,
+	showDeleted bool,
+	ordering []orderby.Field,
+	page pagination.Page,
 ) ([]*entities.Book, error) {
 	var books []*Book
-	err := g.db.ModelContext(ctx, &books).
-		Relation("Shelf").
+	query := whereNotDeleted(g.db.ModelContext(ctx, &books).Relation("Shelf"), showDeleted).
+		Relation("Contributors", orderContributors)
+	query, err := whereFilter(query, expr, bookFilterColumns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter books: %w", err)
+	}
+
+	query, err = paginate(query, ordering, page, bookOrderColumns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to paginate books: %w", err)
+	}
+
+	err = query.
 		Where("book.shelf_name = ?", shelfName).
-		Limit(pageSize).
-		Offset(pageOffset).
 		Select()
 	if err != nil {
 		return nil, fmt.Errorf("failed to select books from shelf in postgres: %w", err)
@@ -76,11 +168,15 @@ func (g *Gateway) ListShelfBooks(
 	return eBooks, nil
 }
 
-func (g *Gateway) CountBooks(ctx context.Context) (int, error) {
+func (g *Gateway) CountBooks(ctx context.Context, expr filter.Expr, showDeleted bool) (int, error) {
 	book := new(Book)
-	count, err := g.db.ModelContext(ctx, book).
-		Relation("Shelf").
-		Count()
+	query := whereNotDeleted(g.db.ModelContext(ctx, book).Relation("Shelf"), showDeleted)
+	query, err := whereFilter(query, expr, bookFilterColumns)
+	if err != nil {
+		return 0, fmt.Errorf("failed to filter books: %w", err)
+	}
+
+	count, err := query.Count()
 	if err != nil {
 		return 0, fmt.Errorf("failed to count books in postgres: %w", err)
 	}
@@ -88,10 +184,20 @@ func (g *Gateway) CountBooks(ctx context.Context) (int, error) {
 	return count, nil
 }
 
-func (g *Gateway) CountShelfBooks(ctx context.Context, shelfName string) (int, error) {
+func (g *Gateway) CountShelfBooks(
+	ctx context.Context,
+	shelfName string,
+	expr filter.Expr,
+	showDeleted bool,
+) (int, error) {
 	book := new(Book)
-	count, err := g.db.ModelContext(ctx, book).
-		Relation("Shelf").
+	query := whereNotDeleted(g.db.ModelContext(ctx, book).Relation("Shelf"), showDeleted)
+	query, err := whereFilter(query, expr, bookFilterColumns)
+	if err != nil {
+		return 0, fmt.Errorf("failed to filter books: %w", err)
+	}
+
+	count, err := query.
 		Where("book.shelf_name = ?", shelfName).
 		Count()
 	if err != nil {
@@ -102,11 +208,12 @@ func (g *Gateway) CountShelfBooks(ctx context.Context, shelfName string) (int, e
 }
 
 // GetBook returns book of name from shelf.
-// If book not found, returns nil book and nil error.
-func (g *Gateway) GetBook(ctx context.Context, shelfName, bookName string) (*entities.Book, error) {
+// If book not found or it is deleted and showDeleted is false, returns nil book and nil error.
+func (g *Gateway) GetBook(ctx context.Context, shelfName, bookName string, showDeleted bool) (*entities.Book, error) {
 	book := new(Book)
-	err := g.db.ModelContext(ctx, book).
+	err := whereNotDeleted(g.db.ModelContext(ctx, book), showDeleted).
 		Relation("Shelf").
+		Relation("Contributors", orderContributors).
 		Where("book.shelf_name = ?", shelfName).
 		Where("book.name = ?", bookName).
 		Select()
@@ -120,23 +227,73 @@ func (g *Gateway) GetBook(ctx context.Context, shelfName, bookName string) (*ent
 	return book.toEntity(), nil
 }
 
+// isShelfViolation tells whether err is caused by a book in a shelf that doesn't exist.
+func isShelfViolation(err error) bool {
+	var pgErr pg.Error
+
+	return errors.As(err, &pgErr) &&
+		pgErr.Field('C') == pgForeignKeyViolation &&
+		pgErr.Field('n') == bookShelfConstraint
+}
+
+// CreateBook inserts the book and its contributors in shelf.
+// If the book already exists, returns nil book and nil error.
+// If shelf or the book author, series or work doesn't exist, returns domainBooks.ErrShelfNotFound,
+// ErrAuthorNotFound, ErrSeriesNotFound or ErrWorkNotFound.
 func (g *Gateway) CreateBook(ctx context.Context, shelfName string, eBook *entities.Book) (*entities.Book, error) {
 	now := time.Now()
 
 	book := &Book{
-		ShelfName:  shelfName,
-		Shelf:      nil,
-		Name:       eBook.Name,
-		Author:     eBook.Author,
-		CreateTime: now,
-		UpdateTime: now,
+		ShelfName:       shelfName,
+		Shelf:           nil,
+		Name:            eBook.Name,
+		Author:          eBook.Author(),
+		Contributors:    toBookContributors(shelfName, eBook.Name, eBook.Contributors),
+		AuthorName:      eBook.AuthorName,
+		SeriesName:      eBook.SeriesName,
+		VolumeNumber:    eBook.VolumeNumber,
+		WorkName:        eBook.WorkName,
+		Title:           eBook.Title,
+		ISBN:            eBook.ISBN,
+		Publisher:       eBook.Publisher,
+		PublicationYear: eBook.PublicationYear,
+		Language:        eBook.Language,
+		PageCount:       eBook.PageCount,
+		Labels:          eBook.Labels,
+		Description:     eBook.Description,
+		CreateTime:      now,
+		UpdateTime:      now,
+		DeleteTime:      time.Time{},
+		PurgeTime:       time.Time{},
+		Version:         1,
 	}
 
-	_, err := g.db.ModelContext(ctx, book).Insert()
+	err := g.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if _, err := tx.ModelContext(ctx, book).Insert(); err != nil {
+			return fmt.Errorf("failed to insert book: %w", err)
+		}
+
+		return insertContributors(ctx, tx, book)
+	})
 	if err != nil {
+		if isAuthorViolation(err) {
+			return nil, domainBooks.ErrAuthorNotFound
+		}
+
+		if isSeriesViolation(err) {
+			return nil, domainBooks.ErrSeriesNotFound
+		}
+
+		if isWorkViolation(err) {
+			return nil, domainBooks.ErrWorkNotFound
+		}
+
+		if isShelfViolation(err) {
+			return nil, domainBooks.ErrShelfNotFound
+		}
+
 		var pgErr pg.Error
-		// TODO: fix when shelf doesn't exist
-		if errors.As(err, &pgErr) && pgErr.IntegrityViolation() {
+		if errors.As(err, &pgErr) && pgErr.Field('C') == pgUniqueViolation {
 			return nil, nil
 		}
 
@@ -146,6 +303,11 @@ func (g *Gateway) CreateBook(ctx context.Context, shelfName string, eBook *entit
 	return book.toEntity(), nil
 }
 
+// UpdateBook updates the fields of book.
+// If eBook has a version, the book is only updated if its version is the same.
+// If book not found or its version is different, returns nil book and nil error.
+// If the book author, series or work doesn't exist, returns domainBooks.ErrAuthorNotFound, ErrSeriesNotFound
+// or ErrWorkNotFound.
 func (g *Gateway) UpdateBook(
 	ctx context.Context,
 	shelfName string,
@@ -155,32 +317,200 @@ func (g *Gateway) UpdateBook(
 	now := time.Now()
 
 	book := &Book{
-		ShelfName:  shelfName,
-		Shelf:      nil,
-		Name:       eBook.Name,
-		Author:     eBook.Author,
-		CreateTime: time.Time{},
-		UpdateTime: now,
+		ShelfName:       shelfName,
+		Shelf:           nil,
+		Name:            eBook.Name,
+		Author:          eBook.Author(),
+		Contributors:    toBookContributors(shelfName, eBook.Name, eBook.Contributors),
+		AuthorName:      eBook.AuthorName,
+		SeriesName:      eBook.SeriesName,
+		VolumeNumber:    eBook.VolumeNumber,
+		WorkName:        eBook.WorkName,
+		Title:           eBook.Title,
+		ISBN:            eBook.ISBN,
+		Publisher:       eBook.Publisher,
+		PublicationYear: eBook.PublicationYear,
+		Language:        eBook.Language,
+		PageCount:       eBook.PageCount,
+		Labels:          eBook.Labels,
+		Description:     eBook.Description,
+		CreateTime:      time.Time{},
+		UpdateTime:      now,
+		DeleteTime:      time.Time{},
+		PurgeTime:       time.Time{},
+		Version:         0,
 	}
 
-	fields = append(fields, "update_time")
+	columns, updateContributors, updateAuthor := bookUpdateColumns(fields)
+
+	err := g.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if updateAuthor {
+			if err := replaceFirstAuthor(ctx, tx, book); err != nil {
+				return err
+			}
+		}
 
-	_, err := g.db.ModelContext(ctx, book).Column(fields...).WherePK().Returning("*").Update()
+		query := tx.ModelContext(ctx, book).
+			Column(columns...).
+			Value("version", "version + 1").
+			WherePK().
+			Where("delete_time IS NULL")
+		if eBook.Version != 0 {
+			query = query.Where("version = ?", eBook.Version)
+		}
+
+		if _, err := query.Returning("*").Update(); err != nil {
+			return fmt.Errorf("failed to update book: %w", err)
+		}
+
+		if updateContributors || updateAuthor {
+			return replaceContributors(ctx, tx, book)
+		}
+
+		return selectContributors(ctx, tx, book)
+	})
 	if err != nil {
 		if errors.Is(err, pg.ErrNoRows) {
 			return nil, nil
 		}
 
-		return nil, fmt.Errorf("failed to insert book in postgres: %w", err)
+		if isAuthorViolation(err) {
+			return nil, domainBooks.ErrAuthorNotFound
+		}
+
+		if isSeriesViolation(err) {
+			return nil, domainBooks.ErrSeriesNotFound
+		}
+
+		if isWorkViolation(err) {
+			return nil, domainBooks.ErrWorkNotFound
+		}
+
+		return nil, fmt.Errorf("failed to update book in postgres: %w", err)
 	}
 
 	// TODO: handle when shelf doesn't exist
 
 	return book.toEntity(), nil
 }
-func (g *Gateway) DeleteBook(ctx context.Context, shelfName, bookName string) (bool, error) {
+
+// bookUpdateColumns returns the columns updated by the book fields.
+// The contributors are in another table, but updating them, or only the first author, updates the author column too.
+func bookUpdateColumns(fields []string) (columns []string, updateContributors, updateAuthor bool) {
+	columns = make([]string, 0, len(fields)+3)
+
+	for _, field := range fields {
+		switch field {
+		case "contributors":
+			updateContributors = true
+			field = "author"
+		case "author":
+			updateAuthor = true
+		case "series":
+			field = "series_name"
+		case "work":
+			field = "work_name"
+		}
+
+		columns = append(columns, field)
+	}
+
+	return append(columns, "update_time", "version"), updateContributors, updateAuthor
+}
+
+// replaceFirstAuthor sets the contributors of book to its current contributors with the first author
+// renamed to the book author. The book is locked until the transaction ends, as every contributors change
+// updates it first, so no change is lost between reading and replacing the contributors.
+// If the book doesn't exist or is deleted, returns pg.ErrNoRows.
+func replaceFirstAuthor(ctx context.Context, tx *pg.Tx, book *Book) error {
+	current := &Book{ShelfName: book.ShelfName, Name: book.Name} //nolint:exhaustivestruct
+
+	err := tx.ModelContext(ctx, current).
+		WherePK().
+		Where("delete_time IS NULL").
+		For("UPDATE").
+		Select()
+	if err != nil {
+		return fmt.Errorf("failed to lock book: %w", err)
+	}
+
+	if err := selectContributors(ctx, tx, current); err != nil {
+		return err
+	}
+
+	eBook := current.toEntity()
+	eBook.ReplaceAuthor(book.Author)
+
+	book.Contributors = toBookContributors(book.ShelfName, book.Name, eBook.Contributors)
+	book.Author = eBook.Author()
+
+	return nil
+}
+
+// MoveBook changes the shelf of a book in a single statement, so create_time is kept.
+// If book not found, returns nil book and nil error.
+// If destination shelf doesn't exist, returns domainBooks.ErrShelfNotFound,
+// and if it already has a book with the same name, domainBooks.ErrBookExists.
+func (g *Gateway) MoveBook(
+	ctx context.Context,
+	shelfName, bookName, destinationShelfName string,
+) (*entities.Book, error) {
+	book := &Book{ShelfName: shelfName, Name: bookName} //nolint:exhaustivestruct
+
+	_, err := g.db.ModelContext(ctx, book).
+		Set("shelf_name = ?", destinationShelfName).
+		Set("update_time = ?", time.Now()).
+		Set("version = version + 1").
+		WherePK().
+		Where("delete_time IS NULL").
+		Returning("*").
+		Update()
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, nil
+		}
+
+		var pgErr pg.Error
+		if errors.As(err, &pgErr) && pgErr.Field('C') == pgUniqueViolation {
+			return nil, domainBooks.ErrBookExists
+		}
+
+		if errors.As(err, &pgErr) && pgErr.Field('C') == pgForeignKeyViolation {
+			return nil, domainBooks.ErrShelfNotFound
+		}
+
+		return nil, fmt.Errorf("failed to move book in postgres: %w", err)
+	}
+
+	// The contributors were moved along with the book by their foreign key.
+	if err := selectContributors(ctx, g.db, book); err != nil {
+		return nil, fmt.Errorf("failed to select moved book contributors: %w", err)
+	}
+
+	return book.toEntity(), nil
+}
+
+// DeleteBook soft deletes the book, which is removed permanently by PurgeBooks after purgeTime.
+// If version is not zero, the book is only deleted if its version is the same.
+// Returns false if book is not found, already deleted or its version is different.
+func (g *Gateway) DeleteBook(
+	ctx context.Context,
+	shelfName, bookName string,
+	version int64,
+	purgeTime time.Time,
+) (bool, error) {
 	book := &Book{ShelfName: shelfName, Name: bookName} //nolint:exhaustivestruct
-	r, err := g.db.ModelContext(ctx, book).WherePK().Delete()
+	query := g.db.ModelContext(ctx, book).
+		Set("delete_time = ?", time.Now()).
+		Set("purge_time = ?", purgeTime).
+		Set("version = version + 1").
+		WherePK().
+		Where("delete_time IS NULL")
+	if version != 0 {
+		query = query.Where("version = ?", version)
+	}
+
+	r, err := query.Update()
 	if err != nil {
 		if errors.Is(err, pg.ErrNoRows) {
 			return false, nil
@@ -193,3 +523,217 @@ func (g *Gateway) DeleteBook(ctx context.Context, shelfName, bookName string) (b
 
 	return deleted, nil
 }
+
+// UndeleteBook restores a soft deleted book.
+// If book not found or it is not deleted, returns nil book and nil error.
+func (g *Gateway) UndeleteBook(ctx context.Context, shelfName, bookName string) (*entities.Book, error) {
+	book := &Book{ShelfName: shelfName, Name: bookName} //nolint:exhaustivestruct
+
+	_, err := g.db.ModelContext(ctx, book).
+		Set("delete_time = NULL").
+		Set("purge_time = NULL").
+		Set("update_time = ?", time.Now()).
+		Set("version = version + 1").
+		WherePK().
+		Where("delete_time IS NOT NULL").
+		Returning("*").
+		Update()
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to undelete book in postgres: %w", err)
+	}
+
+	if err := selectContributors(ctx, g.db, book); err != nil {
+		return nil, fmt.Errorf("failed to select undeleted book contributors: %w", err)
+	}
+
+	return book.toEntity(), nil
+}
+
+// PurgeBooks permanently removes the deleted books whose purge time is before now.
+// Returns the number of books removed.
+func (g *Gateway) PurgeBooks(ctx context.Context, now time.Time) (int, error) {
+	r, err := g.db.ModelContext(ctx, (*Book)(nil)).
+		Where("delete_time IS NOT NULL").
+		Where("purge_time <= ?", now).
+		Delete()
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge books in postgres: %w", err)
+	}
+
+	return r.RowsAffected(), nil
+}
+
+// DeleteShelfBooks removes at most limit books from the shelf.
+// Returns the number of books removed, which is zero when the shelf has no books left.
+func (g *Gateway) DeleteShelfBooks(ctx context.Context, shelfName string, limit int) (int, error) {
+	batch := g.db.ModelContext(ctx, (*Book)(nil)).
+		Column("name").
+		Where("shelf_name = ?", shelfName).
+		Limit(limit)
+
+	r, err := g.db.ModelContext(ctx, (*Book)(nil)).
+		Where("shelf_name = ?", shelfName).
+		Where("name IN (?)", batch).
+		Delete()
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete books from shelf in postgres: %w", err)
+	}
+
+	return r.RowsAffected(), nil
+}
+
+// GetBooks returns the books of names from shelf.
+// Books not found are not returned, so the result may be shorter than bookNames.
+// Deleted books are only returned if showDeleted is true.
+func (g *Gateway) GetBooks(
+	ctx context.Context,
+	shelfName string,
+	bookNames []string,
+	showDeleted bool,
+) ([]*entities.Book, error) {
+	var books []*Book
+	err := whereNotDeleted(g.db.ModelContext(ctx, &books), showDeleted).
+		Relation("Shelf").
+		Relation("Contributors", orderContributors).
+		Where("book.shelf_name = ?", shelfName).
+		Where("book.name IN (?)", pg.In(bookNames)).
+		Select()
+	if err != nil {
+		return nil, fmt.Errorf("failed to select books in postgres: %w", err)
+	}
+
+	eBooks := make([]*entities.Book, len(books))
+	for i, book := range books {
+		eBooks[i] = book.toEntity()
+	}
+
+	return eBooks, nil
+}
+
+// CreateBooks inserts every book in a single statement, so either all or none of them are created.
+// If any book already exists, returns nil books and nil error.
+// If shelf or the author, series or work of any book doesn't exist, returns domainBooks.ErrShelfNotFound,
+// ErrAuthorNotFound, ErrSeriesNotFound or ErrWorkNotFound.
+func (g *Gateway) CreateBooks(
+	ctx context.Context,
+	shelfName string,
+	eBooks []*entities.Book,
+) ([]*entities.Book, error) {
+	now := time.Now()
+
+	books := make([]*Book, len(eBooks))
+	for i, eBook := range eBooks {
+		books[i] = &Book{
+			ShelfName:       shelfName,
+			Shelf:           nil,
+			Name:            eBook.Name,
+			Author:          eBook.Author(),
+			Contributors:    toBookContributors(shelfName, eBook.Name, eBook.Contributors),
+			AuthorName:      eBook.AuthorName,
+			SeriesName:      eBook.SeriesName,
+			VolumeNumber:    eBook.VolumeNumber,
+			WorkName:        eBook.WorkName,
+			Title:           eBook.Title,
+			ISBN:            eBook.ISBN,
+			Publisher:       eBook.Publisher,
+			PublicationYear: eBook.PublicationYear,
+			Language:        eBook.Language,
+			PageCount:       eBook.PageCount,
+			Labels:          eBook.Labels,
+			Description:     eBook.Description,
+			CreateTime:      now,
+			UpdateTime:      now,
+			DeleteTime:      time.Time{},
+			PurgeTime:       time.Time{},
+			Version:         1,
+		}
+	}
+
+	err := g.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if _, err := tx.ModelContext(ctx, &books).Insert(); err != nil {
+			return fmt.Errorf("failed to insert books: %w", err)
+		}
+
+		return insertContributors(ctx, tx, books...)
+	})
+	if err != nil {
+		if isAuthorViolation(err) {
+			return nil, domainBooks.ErrAuthorNotFound
+		}
+
+		if isSeriesViolation(err) {
+			return nil, domainBooks.ErrSeriesNotFound
+		}
+
+		if isWorkViolation(err) {
+			return nil, domainBooks.ErrWorkNotFound
+		}
+
+		var pgErr pg.Error
+		if errors.As(err, &pgErr) && pgErr.Field('C') == pgUniqueViolation {
+			return nil, nil
+		}
+
+		if isShelfViolation(err) {
+			return nil, domainBooks.ErrShelfNotFound
+		}
+
+		return nil, fmt.Errorf("failed to insert books in postgres: %w", err)
+	}
+
+	result := make([]*entities.Book, len(books))
+	for i, book := range books {
+		result[i] = book.toEntity()
+	}
+
+	return result, nil
+}
+
+// DeleteBooks soft deletes the books of names from shelf in a transaction.
+// If any book is not found or already deleted, the transaction is rolled back and returns false.
+func (g *Gateway) DeleteBooks(
+	ctx context.Context,
+	shelfName string,
+	bookNames []string,
+	purgeTime time.Time,
+) (bool, error) {
+	uniqueNames := make(map[string]struct{}, len(bookNames))
+	for _, bookName := range bookNames {
+		uniqueNames[bookName] = struct{}{}
+	}
+
+	errMissingBook := errors.New("book not found")
+
+	err := g.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		r, err := tx.ModelContext(ctx, (*Book)(nil)).
+			Set("delete_time = ?", time.Now()).
+			Set("purge_time = ?", purgeTime).
+			Set("version = version + 1").
+			Where("shelf_name = ?", shelfName).
+			Where("name IN (?)", pg.In(bookNames)).
+			Where("delete_time IS NULL").
+			Update()
+		if err != nil {
+			return fmt.Errorf("failed to delete books in postgres: %w", err)
+		}
+
+		if r.RowsAffected() != len(uniqueNames) {
+			return errMissingBook
+		}
+
+		return nil
+	})
+	if errors.Is(err, errMissingBook) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to run delete books transaction: %w", err)
+	}
+
+	return true, nil
+}
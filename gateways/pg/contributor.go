@@ -0,0 +1,93 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-pg/pg/v10/orm"
+
+	"github.com/Henrod/library/domain/entities"
+)
+
+// BookContributor is a contributor of a book, at the position it is credited.
+// It is removed along with its book and follows it when the book is moved.
+type BookContributor struct {
+	ShelfName string `pg:",pk"`
+	BookName  string `pg:",pk"`
+	Position  int    `pg:",pk,use_zero"`
+	Name      string
+	Role      string
+}
+
+func (c *BookContributor) toEntity() *entities.Contributor {
+	return &entities.Contributor{
+		Name: c.Name,
+		Role: entities.ContributorRole(c.Role),
+	}
+}
+
+func toBookContributors(shelfName, bookName string, eContributors []*entities.Contributor) []*BookContributor {
+	contributors := make([]*BookContributor, len(eContributors))
+	for i, contributor := range eContributors {
+		contributors[i] = &BookContributor{
+			ShelfName: shelfName,
+			BookName:  bookName,
+			Position:  i,
+			Name:      contributor.Name,
+			Role:      string(contributor.Role),
+		}
+	}
+
+	return contributors
+}
+
+// orderContributors sorts the contributors of the selected books in the order they are credited.
+func orderContributors(query *orm.Query) (*orm.Query, error) {
+	return query.Order("book_contributor.position"), nil
+}
+
+// selectContributors selects the contributors of book, as the Contributors relation of selected books.
+// It is used for books returned by insert and update statements.
+func selectContributors(ctx context.Context, db orm.DB, book *Book) error {
+	err := db.ModelContext(ctx, &book.Contributors).
+		Where("book_contributor.shelf_name = ?", book.ShelfName).
+		Where("book_contributor.book_name = ?", book.Name).
+		Order("book_contributor.position").
+		Select()
+	if err != nil {
+		return fmt.Errorf("failed to select book contributors in postgres: %w", err)
+	}
+
+	return nil
+}
+
+// insertContributors inserts the contributors of books, which must have none yet.
+func insertContributors(ctx context.Context, db orm.DB, books ...*Book) error {
+	var contributors []*BookContributor
+	for _, book := range books {
+		contributors = append(contributors, book.Contributors...)
+	}
+
+	if len(contributors) == 0 {
+		return nil
+	}
+
+	if _, err := db.ModelContext(ctx, &contributors).Insert(); err != nil {
+		return fmt.Errorf("failed to insert book contributors in postgres: %w", err)
+	}
+
+	return nil
+}
+
+// replaceContributors replaces every contributor of book by the ones in book.Contributors.
+func replaceContributors(ctx context.Context, db orm.DB, book *Book) error {
+	_, err := db.ModelContext(ctx, (*BookContributor)(nil)).
+		Where("shelf_name = ?", book.ShelfName).
+		Where("book_name = ?", book.Name).
+		Delete()
+	if err != nil {
+		return fmt.Errorf("failed to delete book contributors in postgres: %w", err)
+	}
+
+	return insertContributors(ctx, db, book)
+}
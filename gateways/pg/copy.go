@@ -0,0 +1,206 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+
+	"github.com/Henrod/library/domain/entities"
+	domainErrors "github.com/Henrod/library/domain/errors"
+	"github.com/Henrod/library/domain/filter"
+	"github.com/Henrod/library/domain/orderby"
+	"github.com/Henrod/library/domain/pagination"
+)
+
+// copyBarcodeConstraint makes the copy barcodes unique in the library.
+const copyBarcodeConstraint = "unique_barcode"
+
+// bookCopyNotDeletedCondition matches the copies whose book is not deleted.
+const bookCopyNotDeletedCondition = "EXISTS (SELECT 1 FROM books AS book " +
+	"WHERE book.shelf_name = book_copy.shelf_name AND book.name = book_copy.book_name AND book.delete_time IS NULL)"
+
+// copyTable has the copy queries shared by the resources.
+var copyTable = resourceTable{
+	noun:       "copy",
+	primaryKey: "book_copies_pkey",
+	columns:    resourceColumns("book_copy", "name", "barcode", "condition", "status", "create_time", "update_time"),
+	bookColumn: "",
+}
+
+// BookCopy is a physical copy of a book.
+// It is removed along with its book and follows it when the book is moved.
+type BookCopy struct {
+	ShelfName       string `pg:",pk"`
+	BookName        string `pg:",pk"`
+	Name            string `pg:",pk"`
+	Barcode         string
+	AcquisitionDate time.Time `pg:"type:date"`
+	Condition       string
+	Status          string
+	CreateTime      time.Time
+	UpdateTime      time.Time
+}
+
+func (c *BookCopy) toEntity() *entities.Copy {
+	return &entities.Copy{
+		Name:            c.Name,
+		ShelfName:       c.ShelfName,
+		BookName:        c.BookName,
+		Barcode:         c.Barcode,
+		AcquisitionDate: c.AcquisitionDate,
+		Condition:       entities.CopyCondition(c.Condition),
+		Status:          entities.CopyStatus(c.Status),
+		CreateTime:      c.CreateTime,
+		UpdateTime:      c.UpdateTime,
+	}
+}
+
+// isBarcodeViolation tells whether err is caused by a copy with the barcode of another copy.
+func isBarcodeViolation(err error) bool {
+	var pgErr pg.Error
+
+	return errors.As(err, &pgErr) &&
+		pgErr.Field('C') == pgUniqueViolation &&
+		pgErr.Field('n') == copyBarcodeConstraint
+}
+
+// barcodeTaken returns the AlreadyExistsError of a barcode violation.
+// The barcodes of the copies of a deleted book stay taken until the book is purged, so it can be undeleted.
+func barcodeTaken(barcode string) error {
+	return domainErrors.AlreadyExistsError{
+		Details: fmt.Sprintf("another copy has barcode %s, which may be of a deleted book not purged yet", barcode),
+	}
+}
+
+func (g *Gateway) ListCopies(
+	ctx context.Context,
+	shelfName, bookName string,
+	expr filter.Expr,
+	ordering []orderby.Field,
+	page pagination.Page,
+) ([]*entities.Copy, error) {
+	var copies []*BookCopy
+	query := g.db.ModelContext(ctx, &copies).
+		Where("book_copy.shelf_name = ?", shelfName).
+		Where("book_copy.book_name = ?", bookName)
+
+	err := copyTable.list(query, expr, ordering, page)
+	if err != nil {
+		return nil, err
+	}
+
+	eCopies := make([]*entities.Copy, len(copies))
+	for i, bookCopy := range copies {
+		eCopies[i] = bookCopy.toEntity()
+	}
+
+	return eCopies, nil
+}
+
+// CreateCopy inserts the copy.
+// If the copy already exists, returns nil copy and nil error.
+// If the book doesn't exist, returns NotFoundError, and if another copy has the barcode,
+// even a copy of a deleted book not purged yet, AlreadyExistsError.
+func (g *Gateway) CreateCopy(ctx context.Context, eCopy *entities.Copy) (*entities.Copy, error) {
+	now := time.Now()
+
+	bookCopy := &BookCopy{
+		ShelfName:       eCopy.ShelfName,
+		BookName:        eCopy.BookName,
+		Name:            eCopy.Name,
+		Barcode:         eCopy.Barcode,
+		AcquisitionDate: eCopy.AcquisitionDate,
+		Condition:       string(eCopy.Condition),
+		Status:          string(eCopy.Status),
+		CreateTime:      now,
+		UpdateTime:      now,
+	}
+
+	created, err := copyTable.insert(g.db.ModelContext(ctx, bookCopy))
+	if isBarcodeViolation(err) {
+		return nil, barcodeTaken(eCopy.Barcode)
+	}
+
+	var pgErr pg.Error
+	if errors.As(err, &pgErr) && pgErr.Field('C') == pgForeignKeyViolation {
+		return nil, domainErrors.NotFoundError{
+			Details: fmt.Sprintf("book %s at shelf %s not found", eCopy.BookName, eCopy.ShelfName),
+		}
+	}
+
+	if !created {
+		return nil, err
+	}
+
+	return bookCopy.toEntity(), nil
+}
+
+// GetCopy returns the copy of the book.
+// If the copy is not found or its book is deleted, returns nil copy and nil error.
+func (g *Gateway) GetCopy(ctx context.Context, shelfName, bookName, copyName string) (*entities.Copy, error) {
+	bookCopy := &BookCopy{ShelfName: shelfName, BookName: bookName, Name: copyName} //nolint:exhaustivestruct
+
+	found, err := copyTable.get(g.db.ModelContext(ctx, bookCopy).WherePK().Where(bookCopyNotDeletedCondition))
+	if !found {
+		return nil, err
+	}
+
+	return bookCopy.toEntity(), nil
+}
+
+// GetCopyByBarcode returns the copy with the barcode, from any book.
+// If no copy has the barcode or its book is deleted, returns nil copy and nil error.
+func (g *Gateway) GetCopyByBarcode(ctx context.Context, barcode string) (*entities.Copy, error) {
+	bookCopy := new(BookCopy)
+
+	query := g.db.ModelContext(ctx, bookCopy).
+		Where("book_copy.barcode = ?", barcode).
+		Where(bookCopyNotDeletedCondition)
+
+	found, err := copyTable.get(query)
+	if !found {
+		return nil, err
+	}
+
+	return bookCopy.toEntity(), nil
+}
+
+// UpdateCopy updates only the copy columns in fields.
+// If the copy is not found or its book is deleted, returns nil copy and nil error.
+// If another copy has the barcode, even a copy of a deleted book not purged yet, returns AlreadyExistsError.
+func (g *Gateway) UpdateCopy(ctx context.Context, eCopy *entities.Copy, fields []string) (*entities.Copy, error) {
+	bookCopy := &BookCopy{
+		ShelfName:       eCopy.ShelfName,
+		BookName:        eCopy.BookName,
+		Name:            eCopy.Name,
+		Barcode:         eCopy.Barcode,
+		AcquisitionDate: eCopy.AcquisitionDate,
+		Condition:       string(eCopy.Condition),
+		Status:          string(eCopy.Status),
+		CreateTime:      time.Time{},
+		UpdateTime:      time.Now(),
+	}
+
+	query := g.db.ModelContext(ctx, bookCopy).WherePK().Where(bookCopyNotDeletedCondition)
+
+	found, err := copyTable.update(query, fields)
+	if isBarcodeViolation(err) {
+		return nil, barcodeTaken(eCopy.Barcode)
+	}
+
+	if !found {
+		return nil, err
+	}
+
+	return bookCopy.toEntity(), nil
+}
+
+// DeleteCopy removes the copy, returning false if it is not found or its book is deleted.
+func (g *Gateway) DeleteCopy(ctx context.Context, shelfName, bookName, copyName string) (bool, error) {
+	bookCopy := &BookCopy{ShelfName: shelfName, BookName: bookName, Name: copyName} //nolint:exhaustivestruct
+
+	return copyTable.delete(g.db.ModelContext(ctx, bookCopy).WherePK().Where(bookCopyNotDeletedCondition))
+}
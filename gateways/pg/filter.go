@@ -0,0 +1,160 @@
+package pg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-pg/pg/v10/orm"
+
+	"github.com/Henrod/library/domain/filter"
+)
+
+// bookFilterColumns maps the book filterable fields to their columns.
+// Only fields in this map are ever written into the SQL, values are always parameters.
+// Books refer to their author, series and work by ID, which are compared as resource names.
+var bookFilterColumns = map[string]string{
+	"name":             "book.name",
+	"author":           "book.author",
+	"title":            "book.title",
+	"isbn":             "book.isbn",
+	"publisher":        "book.publisher",
+	"publication_year": "book.publication_year",
+	"language":         "book.language",
+	"page_count":       "book.page_count",
+	"create_time":      "book.create_time",
+	"update_time":      "book.update_time",
+	"labels":           "book.labels",
+	"contributor":      "book_contributor.name",
+	"contributors":     "book_contributor.name",
+	"author_name":      "('authors/' || book.author_name)",
+	"series":           "('series/' || book.series_name)",
+	"work":             "('works/' || book.work_name)",
+}
+
+// bookContributorCondition matches the books with any contributor satisfying a condition on book_contributor.
+const bookContributorCondition = "EXISTS (SELECT 1 FROM book_contributors AS book_contributor " +
+	"WHERE book_contributor.shelf_name = book.shelf_name AND book_contributor.book_name = book.name AND %s)"
+
+// shelfFilterColumns maps the shelf filterable fields to their columns.
+var shelfFilterColumns = map[string]string{
+	"name":         "shelf.name",
+	"display_name": "shelf.display_name",
+	"create_time":  "shelf.create_time",
+	"update_time":  "shelf.update_time",
+	"labels":       "shelf.labels",
+}
+
+// whereFilter adds the filter expression as a condition to query.
+// Nil expression adds no condition.
+func whereFilter(query *orm.Query, expr filter.Expr, columns map[string]string) (*orm.Query, error) {
+	if expr == nil {
+		return query, nil
+	}
+
+	condition, params, err := filterToSQL(expr, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	return query.Where(condition, params...), nil
+}
+
+func filterToSQL(expr filter.Expr, columns map[string]string) (string, []interface{}, error) {
+	switch e := expr.(type) {
+	case filter.And:
+		return binaryToSQL("AND", e.Left, e.Right, columns)
+	case filter.Or:
+		return binaryToSQL("OR", e.Left, e.Right, columns)
+	case filter.Not:
+		condition, params, err := filterToSQL(e.Expr, columns)
+		if err != nil {
+			return "", nil, err
+		}
+
+		return fmt.Sprintf("NOT (%s)", condition), params, nil
+	case filter.Comparison:
+		column, ok := columns[e.Field]
+		if !ok {
+			return "", nil, fmt.Errorf("field %s has no column", e.Field)
+		}
+
+		if e.Operator == filter.Has {
+			return hasToSQL(column, e), hasParams(e), nil
+		}
+
+		operator := string(e.Operator)
+		if e.Operator == filter.NotEquals {
+			operator = "<>"
+		}
+
+		if strings.HasPrefix(column, "book_contributor.") {
+			return contributorToSQL(column, operator, e)
+		}
+
+		if e.Key != "" {
+			// Map columns are JSONB objects, compared by the text value of the key.
+			return fmt.Sprintf("%s ->> ? %s ?", column, operator), []interface{}{e.Key, e.Value}, nil
+		}
+
+		return fmt.Sprintf("%s %s ?", column, operator), []interface{}{e.Value}, nil
+	}
+
+	return "", nil, fmt.Errorf("unknown filter expression %T", expr)
+}
+
+// contributorToSQL compares the column of any contributor of the book.
+// The key is the contributor role, e.g. contributors.translator = "Jane" matches books translated by Jane.
+func contributorToSQL(column, operator string, e filter.Comparison) (string, []interface{}, error) {
+	if e.Key != "" {
+		condition := fmt.Sprintf("book_contributor.role = ? AND %s %s ?", column, operator)
+
+		return fmt.Sprintf(bookContributorCondition, condition), []interface{}{e.Key, e.Value}, nil
+	}
+
+	condition := fmt.Sprintf("%s %s ?", column, operator)
+
+	return fmt.Sprintf(bookContributorCondition, condition), []interface{}{e.Value}, nil
+}
+
+// hasToSQL matches the rows with the column, or the key of the column, present.
+// The key of contributors is the role, e.g. contributors:translator matches books with a translator.
+func hasToSQL(column string, e filter.Comparison) string {
+	switch {
+	case strings.HasPrefix(column, "book_contributor.") && e.Key != "":
+		return fmt.Sprintf(bookContributorCondition, "book_contributor.role = ?")
+	case strings.HasPrefix(column, "book_contributor."):
+		return fmt.Sprintf(bookContributorCondition, column+" IS NOT NULL")
+	case e.Key != "":
+		return fmt.Sprintf("%s ->> ? IS NOT NULL", column)
+	}
+
+	return fmt.Sprintf("%s IS NOT NULL", column)
+}
+
+func hasParams(e filter.Comparison) []interface{} {
+	if e.Key != "" {
+		return []interface{}{e.Key}
+	}
+
+	return nil
+}
+
+func binaryToSQL(operator string, left, right filter.Expr, columns map[string]string) (string, []interface{}, error) {
+	leftCondition, leftParams, err := filterToSQL(left, columns)
+	if err != nil {
+		return "", nil, err
+	}
+
+	rightCondition, rightParams, err := filterToSQL(right, columns)
+	if err != nil {
+		return "", nil, err
+	}
+
+	condition := strings.Join([]string{
+		"(" + leftCondition + ")",
+		operator,
+		"(" + rightCondition + ")",
+	}, " ")
+
+	return condition, append(leftParams, rightParams...), nil
+}
@@ -0,0 +1,79 @@
+-- Migrates a library created before the authors resource. Run it once, before deploying the authors API.
+-- Every distinct free-text book author becomes an author, whose ID is the author text without accents,
+-- in lowercase and with every run of other characters than letters and digits replaced by '-',
+-- e.g. "Henrod" is authors/henrod and "José Saramago" is authors/jose-saramago.
+-- Texts without letters nor digits, e.g. "李白" or "???", get the ID "author-" followed by the first 12 hex
+-- digits of the text MD5, as authors/author-0123456789ab.
+-- Books are linked to the author of their text, which is kept as it is, and get a new etag.
+--
+-- Texts with the same ID, e.g. "Henrod" and "henrod.", share one author and are reported by a NOTICE,
+-- as are texts linked to an existing author of another display name. Check the notices before deploying.
+-- Authors with different texts, as "Henrod" and "Henrique Rodrigues", must be merged by linking their books
+-- to one of them with UpdateBook and deleting the other one.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS authors (
+    name TEXT PRIMARY KEY,
+    display_name TEXT NOT NULL,
+    create_time TIMESTAMP,
+    update_time TIMESTAMP
+);
+
+ALTER TABLE books ADD COLUMN IF NOT EXISTS author_name TEXT;
+
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+CREATE TEMPORARY TABLE book_author_ids ON COMMIT DROP AS
+SELECT DISTINCT
+    author,
+    trim(BOTH '-' FROM regexp_replace(lower(unaccent(author)), '[^a-z0-9]+', '-', 'g')) AS author_id
+FROM books
+WHERE author_name IS NULL AND author IS NOT NULL AND author <> '';
+
+UPDATE book_author_ids
+SET author_id = 'author-' || left(md5(author), 12)
+WHERE author_id = '';
+
+DO $$
+DECLARE
+    collision RECORD;
+BEGIN
+    FOR collision IN
+        SELECT author_id, string_agg(quote_literal(author), ', ' ORDER BY author) AS authors
+        FROM book_author_ids
+        GROUP BY author_id
+        HAVING count(*) > 1
+    LOOP
+        RAISE NOTICE 'authors/% is shared by the texts %', collision.author_id, collision.authors;
+    END LOOP;
+
+    FOR collision IN
+        SELECT book_author_ids.author_id, authors.display_name, book_author_ids.author
+        FROM book_author_ids
+        JOIN authors ON authors.name = book_author_ids.author_id
+        WHERE authors.display_name <> book_author_ids.author
+    LOOP
+        RAISE NOTICE 'authors/% already exists as %, the books of % are linked to it',
+            collision.author_id, quote_literal(collision.display_name), quote_literal(collision.author);
+    END LOOP;
+END $$;
+
+-- Texts differing only in case or punctuation share the author, named after the first text.
+INSERT INTO authors (name, display_name, create_time, update_time)
+SELECT DISTINCT ON (author_id) author_id, author, now(), now()
+FROM book_author_ids
+ORDER BY author_id, author
+ON CONFLICT (name) DO NOTHING;
+
+UPDATE books
+SET author_name = book_author_ids.author_id, version = version + 1
+FROM book_author_ids
+WHERE books.author_name IS NULL AND books.author = book_author_ids.author;
+
+ALTER TABLE books
+    ADD CONSTRAINT fk_author FOREIGN KEY (author_name) REFERENCES authors (name);
+
+CREATE INDEX IF NOT EXISTS books_author_name_idx ON books (author_name, shelf_name, name);
+
+COMMIT;
@@ -0,0 +1,26 @@
+-- Migrates a library created before book contributors. Run it once, before deploying contributors.
+-- The author of every book becomes its single author contributor.
+-- The author column is kept, as the first author contributor, to search, sort and filter books by it.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS book_contributors (
+    shelf_name TEXT,
+    book_name TEXT,
+    position INTEGER,
+    name TEXT NOT NULL,
+    role TEXT NOT NULL,
+    CONSTRAINT fk_book FOREIGN KEY (book_name, shelf_name) REFERENCES books (name, shelf_name)
+        ON UPDATE CASCADE ON DELETE CASCADE,
+    PRIMARY KEY (shelf_name, book_name, position)
+);
+
+CREATE INDEX IF NOT EXISTS book_contributors_name_idx ON book_contributors (name, role);
+
+INSERT INTO book_contributors (shelf_name, book_name, position, name, role)
+SELECT shelf_name, name, 0, author, 'author'
+FROM books
+WHERE author IS NOT NULL AND author <> ''
+ON CONFLICT DO NOTHING;
+
+COMMIT;
@@ -0,0 +1,22 @@
+-- Migrates a library created before the copies resource. Run it once, before deploying the copies API.
+-- Existing books have no copies until they are created with CreateCopy.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS book_copies (
+    shelf_name TEXT,
+    book_name TEXT,
+    name TEXT,
+    barcode TEXT NOT NULL,
+    acquisition_date DATE,
+    condition TEXT NOT NULL,
+    status TEXT NOT NULL,
+    create_time TIMESTAMP,
+    update_time TIMESTAMP,
+    CONSTRAINT fk_book FOREIGN KEY (book_name, shelf_name) REFERENCES books (name, shelf_name)
+        ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT unique_barcode UNIQUE (barcode),
+    PRIMARY KEY (shelf_name, book_name, name)
+);
+
+COMMIT;
@@ -2,8 +2,34 @@
 
 CREATE DATABASE library;
 
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
 CREATE TABLE shelves (
     name TEXT PRIMARY KEY,
+    display_name TEXT,
+    labels JSONB,
+    create_time TIMESTAMP,
+    update_time TIMESTAMP
+);
+
+CREATE TABLE authors (
+    name TEXT PRIMARY KEY,
+    display_name TEXT NOT NULL,
+    create_time TIMESTAMP,
+    update_time TIMESTAMP
+);
+
+CREATE TABLE series (
+    name TEXT PRIMARY KEY,
+    display_name TEXT NOT NULL,
+    volume_count INTEGER,
+    create_time TIMESTAMP,
+    update_time TIMESTAMP
+);
+
+CREATE TABLE works (
+    name TEXT PRIMARY KEY,
+    display_name TEXT NOT NULL,
     create_time TIMESTAMP,
     update_time TIMESTAMP
 );
@@ -11,9 +37,80 @@ CREATE TABLE shelves (
 CREATE TABLE books (
     name TEXT,
     author TEXT,
+    author_name TEXT,
+    series_name TEXT,
+    volume_number INTEGER,
+    work_name TEXT,
+    title TEXT,
+    isbn TEXT,
+    publisher TEXT,
+    publication_year INTEGER,
+    language TEXT,
+    page_count INTEGER,
+    labels JSONB,
+    description TEXT,
     shelf_name TEXT,
     create_time TIMESTAMP,
     update_time TIMESTAMP,
+    delete_time TIMESTAMP,
+    purge_time TIMESTAMP,
+    version BIGINT NOT NULL DEFAULT 1,
+    search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', COALESCE(title, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE(author, '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(description, '')), 'C')
+    ) STORED,
     CONSTRAINT fk_shelf FOREIGN KEY (shelf_name) REFERENCES shelves (name),
+    CONSTRAINT fk_author FOREIGN KEY (author_name) REFERENCES authors (name),
+    CONSTRAINT fk_series FOREIGN KEY (series_name) REFERENCES series (name),
+    CONSTRAINT fk_work FOREIGN KEY (work_name) REFERENCES works (name),
     PRIMARY KEY (name, shelf_name)
-);
\ No newline at end of file
+);
+
+CREATE INDEX books_search_vector_idx ON books USING GIN (search_vector);
+CREATE INDEX books_title_trgm_idx ON books USING GIN (title gin_trgm_ops);
+CREATE INDEX books_author_trgm_idx ON books USING GIN (author gin_trgm_ops);
+CREATE INDEX books_author_name_idx ON books (author_name, shelf_name, name);
+CREATE INDEX books_series_name_idx ON books (series_name, volume_number, shelf_name, name);
+CREATE INDEX books_work_name_idx ON books (work_name, shelf_name, name);
+
+-- The first author contributor of a book is also in its author column, to search, sort and filter by it.
+CREATE TABLE book_contributors (
+    shelf_name TEXT,
+    book_name TEXT,
+    position INTEGER,
+    name TEXT NOT NULL,
+    role TEXT NOT NULL,
+    CONSTRAINT fk_book FOREIGN KEY (book_name, shelf_name) REFERENCES books (name, shelf_name)
+        ON UPDATE CASCADE ON DELETE CASCADE,
+    PRIMARY KEY (shelf_name, book_name, position)
+);
+
+CREATE INDEX book_contributors_name_idx ON book_contributors (name, role);
+
+-- Copies are the physical items of a book, found by their barcode when scanned.
+CREATE TABLE book_copies (
+    shelf_name TEXT,
+    book_name TEXT,
+    name TEXT,
+    barcode TEXT NOT NULL,
+    acquisition_date DATE,
+    condition TEXT NOT NULL,
+    status TEXT NOT NULL,
+    create_time TIMESTAMP,
+    update_time TIMESTAMP,
+    CONSTRAINT fk_book FOREIGN KEY (book_name, shelf_name) REFERENCES books (name, shelf_name)
+        ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT unique_barcode UNIQUE (barcode),
+    PRIMARY KEY (shelf_name, book_name, name)
+);
+
+CREATE TABLE requests (
+    id TEXT PRIMARY KEY,
+    request_hash BYTEA,
+    response BYTEA,
+    complete_time TIMESTAMP,
+    expire_time TIMESTAMP
+);
+
+CREATE INDEX requests_expire_time_idx ON requests (expire_time);
@@ -0,0 +1,23 @@
+-- Migrates a library created before the series resource. Run it once, before deploying the series API.
+-- Existing books are not part of any series until they are added to one with UpdateBook.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS series (
+    name TEXT PRIMARY KEY,
+    display_name TEXT NOT NULL,
+    volume_count INTEGER,
+    create_time TIMESTAMP,
+    update_time TIMESTAMP
+);
+
+ALTER TABLE books
+    ADD COLUMN IF NOT EXISTS series_name TEXT,
+    ADD COLUMN IF NOT EXISTS volume_number INTEGER;
+
+ALTER TABLE books
+    ADD CONSTRAINT fk_series FOREIGN KEY (series_name) REFERENCES series (name);
+
+CREATE INDEX IF NOT EXISTS books_series_name_idx ON books (series_name, volume_number, shelf_name, name);
+
+COMMIT;
@@ -0,0 +1,20 @@
+-- Migrates a library created before the works resource. Run it once, before deploying the works API.
+-- Existing books are not editions of any work until they are linked to one with UpdateBook.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS works (
+    name TEXT PRIMARY KEY,
+    display_name TEXT NOT NULL,
+    create_time TIMESTAMP,
+    update_time TIMESTAMP
+);
+
+ALTER TABLE books ADD COLUMN IF NOT EXISTS work_name TEXT;
+
+ALTER TABLE books
+    ADD CONSTRAINT fk_work FOREIGN KEY (work_name) REFERENCES works (name);
+
+CREATE INDEX IF NOT EXISTS books_work_name_idx ON books (work_name, shelf_name, name);
+
+COMMIT;
@@ -0,0 +1,131 @@
+package pg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-pg/pg/v10/orm"
+
+	"github.com/Henrod/library/domain/orderby"
+	"github.com/Henrod/library/domain/pagination"
+)
+
+// bookOrderColumns maps the book sortable fields to their columns.
+// Nullable columns are coalesced, so they can be compared in the page cursor.
+var bookOrderColumns = map[string]string{
+	"shelf_name":       "book.shelf_name",
+	"name":             "book.name",
+	"author":           "COALESCE(book.author, '')",
+	"title":            "COALESCE(book.title, '')",
+	"publication_year": "COALESCE(book.publication_year, 0)",
+	"volume_number":    "COALESCE(book.volume_number, 0)",
+	"create_time":      "book.create_time",
+	"update_time":      "book.update_time",
+}
+
+// shelfOrderColumns maps the shelf sortable fields to their columns.
+// Nullable columns are coalesced, so they can be compared in the page cursor.
+var shelfOrderColumns = map[string]string{
+	"name":         "shelf.name",
+	"display_name": "COALESCE(shelf.display_name, '')",
+	"create_time":  "shelf.create_time",
+	"update_time":  "shelf.update_time",
+}
+
+// paginate sorts query by ordering and selects the rows of page.
+// The ordering must be deterministic, which is the domain responsibility.
+func paginate(
+	query *orm.Query,
+	ordering []orderby.Field,
+	page pagination.Page,
+	columns map[string]string,
+) (*orm.Query, error) {
+	sortColumns := make([]string, len(ordering))
+
+	for i, field := range ordering {
+		column, ok := columns[field.Name]
+		if !ok {
+			return nil, fmt.Errorf("field %s has no column", field.Name)
+		}
+
+		direction := "ASC"
+		if field.Descending {
+			direction = "DESC"
+		}
+
+		query = query.OrderExpr(fmt.Sprintf("%s %s", column, direction))
+		sortColumns[i] = column
+	}
+
+	query = query.Offset(page.Skip).Limit(page.Size)
+
+	if page.After == nil {
+		return query, nil
+	}
+
+	if len(page.After) != len(ordering) {
+		return nil, fmt.Errorf("cursor has %d values for %d sort fields", len(page.After), len(ordering))
+	}
+
+	condition := keysetCondition(ordering, sortColumns)
+
+	return query.Where(condition, keysetParams(ordering, page.After)...), nil
+}
+
+// keysetCondition returns the condition of rows sorted after the cursor.
+// If every field has the same direction, a row comparison is used, e.g. "(a, b) > (?, ?)",
+// otherwise it is expanded to "a > ? OR (a = ? AND b < ?)".
+func keysetCondition(ordering []orderby.Field, columns []string) string {
+	if sameDirection(ordering) {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+
+		return fmt.Sprintf("(%s) %s (%s)",
+			strings.Join(columns, ", "), afterOperator(ordering[0]), placeholders)
+	}
+
+	disjunction := make([]string, len(columns))
+
+	for i := range columns {
+		conjunction := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			conjunction = append(conjunction, fmt.Sprintf("%s = ?", columns[j]))
+		}
+
+		conjunction = append(conjunction, fmt.Sprintf("%s %s ?", columns[i], afterOperator(ordering[i])))
+		disjunction[i] = "(" + strings.Join(conjunction, " AND ") + ")"
+	}
+
+	return strings.Join(disjunction, " OR ")
+}
+
+// keysetParams returns the cursor values in the order of keysetCondition placeholders.
+func keysetParams(ordering []orderby.Field, after pagination.Cursor) []interface{} {
+	if sameDirection(ordering) {
+		return after
+	}
+
+	params := make([]interface{}, 0, len(after)*(len(after)+1)/2)
+	for i := range after {
+		params = append(params, after[:i+1]...)
+	}
+
+	return params
+}
+
+func sameDirection(ordering []orderby.Field) bool {
+	for _, field := range ordering {
+		if field.Descending != ordering[0].Descending {
+			return false
+		}
+	}
+
+	return true
+}
+
+func afterOperator(field orderby.Field) string {
+	if field.Descending {
+		return "<"
+	}
+
+	return ">"
+}
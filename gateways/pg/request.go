@@ -0,0 +1,111 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+
+	"github.com/Henrod/library/domain/entities"
+)
+
+type Request struct {
+	ID           string `pg:",pk"`
+	RequestHash  []byte
+	Response     []byte
+	CompleteTime time.Time
+	ExpireTime   time.Time
+}
+
+func (r *Request) toEntity() *entities.Request {
+	return &entities.Request{
+		ID:           r.ID,
+		RequestHash:  r.RequestHash,
+		Response:     r.Response,
+		CompleteTime: r.CompleteTime,
+		ExpireTime:   r.ExpireTime,
+	}
+}
+
+// CreateRequest stores the request, removing the expired ones first, so their IDs can be reused.
+// If a request with the same ID exists, returns nil request and nil error.
+func (g *Gateway) CreateRequest(ctx context.Context, eRequest *entities.Request) (*entities.Request, error) {
+	_, err := g.db.ModelContext(ctx, (*Request)(nil)).
+		Where("expire_time <= ?", time.Now()).
+		Delete()
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete expired requests in postgres: %w", err)
+	}
+
+	request := &Request{
+		ID:           eRequest.ID,
+		RequestHash:  eRequest.RequestHash,
+		Response:     eRequest.Response,
+		CompleteTime: eRequest.CompleteTime,
+		ExpireTime:   eRequest.ExpireTime,
+	}
+
+	r, err := g.db.ModelContext(ctx, request).OnConflict("DO NOTHING").Insert()
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert request in postgres: %w", err)
+	}
+
+	if r.RowsAffected() == 0 {
+		return nil, nil
+	}
+
+	return request.toEntity(), nil
+}
+
+// GetRequest returns the request of ID if it is not expired.
+// If request not found, returns nil request and nil error.
+func (g *Gateway) GetRequest(ctx context.Context, requestID string) (*entities.Request, error) {
+	request := new(Request)
+	err := g.db.ModelContext(ctx, request).
+		Where("id = ?", requestID).
+		Where("expire_time > ?", time.Now()).
+		Select()
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to select request in postgres: %w", err)
+	}
+
+	return request.toEntity(), nil
+}
+
+// CompleteRequest stores the response of the request, which is kept until expireTime.
+func (g *Gateway) CompleteRequest(
+	ctx context.Context,
+	requestID string,
+	response []byte,
+	expireTime time.Time,
+) error {
+	request := &Request{ID: requestID} //nolint:exhaustivestruct
+
+	_, err := g.db.ModelContext(ctx, request).
+		Set("response = ?", response).
+		Set("complete_time = ?", time.Now()).
+		Set("expire_time = ?", expireTime).
+		WherePK().
+		Update()
+	if err != nil {
+		return fmt.Errorf("failed to update request in postgres: %w", err)
+	}
+
+	return nil
+}
+
+func (g *Gateway) DeleteRequest(ctx context.Context, requestID string) error {
+	request := &Request{ID: requestID} //nolint:exhaustivestruct
+
+	_, err := g.db.ModelContext(ctx, request).WherePK().Delete()
+	if err != nil {
+		return fmt.Errorf("failed to delete request in postgres: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/filter"
+	"github.com/Henrod/library/domain/orderby"
+	"github.com/Henrod/library/domain/pagination"
+)
+
+// resourceTable has the queries shared by the resources stored in a table of their own, e.g. the authors.
+// They run on queries of the table model, e.g. *Author, or of a slice of them to list.
+type resourceTable struct {
+	// noun names the resource in the errors, e.g. "author".
+	noun string
+	// primaryKey is the constraint violated by inserting a resource that already exists, e.g. "authors_pkey".
+	primaryKey string
+	// columns maps the filterable and sortable resource fields to their columns.
+	// Only fields in this map are ever written into the SQL, values are always parameters.
+	columns map[string]string
+	// bookColumn is the book column referring to the resource, e.g. "book.author_name".
+	// It is empty if books don't refer to the resource.
+	bookColumn string
+}
+
+// resourceColumns maps the fields of a resource to the columns of the same name in the table of alias.
+func resourceColumns(alias string, fields ...string) map[string]string {
+	columns := make(map[string]string, len(fields))
+	for _, field := range fields {
+		columns[field] = alias + "." + field
+	}
+
+	return columns
+}
+
+// namedColumns maps the fields of a resource with a display name, e.g. an author, to their columns.
+func namedColumns(alias string) map[string]string {
+	return resourceColumns(alias, "name", "display_name", "create_time", "update_time")
+}
+
+// list selects into the query model the page of resources matching expr sorted by ordering.
+func (t resourceTable) list(
+	query *orm.Query,
+	expr filter.Expr,
+	ordering []orderby.Field,
+	page pagination.Page,
+) error {
+	query, err := whereFilter(query, expr, t.columns)
+	if err != nil {
+		return fmt.Errorf("failed to filter %s list: %w", t.noun, err)
+	}
+
+	query, err = paginate(query, ordering, page, t.columns)
+	if err != nil {
+		return fmt.Errorf("failed to paginate %s list: %w", t.noun, err)
+	}
+
+	err = query.Select()
+	if err != nil {
+		return fmt.Errorf("failed to select %s list in postgres: %w", t.noun, err)
+	}
+
+	return nil
+}
+
+// insert inserts the query model, returning false if the resource already exists.
+func (t resourceTable) insert(query *orm.Query) (bool, error) {
+	_, err := query.Insert()
+	if err != nil {
+		var pgErr pg.Error
+		if errors.As(err, &pgErr) && pgErr.Field('C') == pgUniqueViolation && pgErr.Field('n') == t.primaryKey {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to insert %s in postgres: %w", t.noun, err)
+	}
+
+	return true, nil
+}
+
+// get selects the resource into the query model, returning false if it is not found.
+func (t resourceTable) get(query *orm.Query) (bool, error) {
+	err := query.Select()
+	if errors.Is(err, pg.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to select %s in postgres: %w", t.noun, err)
+	}
+
+	return true, nil
+}
+
+// update updates the columns of fields and the update time from the query model,
+// which is set to the updated resource, returning false if it is not found.
+func (t resourceTable) update(query *orm.Query, fields []string) (bool, error) {
+	_, err := query.Column(fields...).Column("update_time").Returning("*").Update()
+	if errors.Is(err, pg.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to update %s in postgres: %w", t.noun, err)
+	}
+
+	return true, nil
+}
+
+// delete removes the resources of query, returning false if none is found.
+func (t resourceTable) delete(query *orm.Query) (bool, error) {
+	r, err := query.Delete()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete %s in postgres: %w", t.noun, err)
+	}
+
+	return r.RowsAffected() > 0, nil
+}
+
+// listBooks returns the page of not deleted books referring to the resource of name, from every shelf.
+func (t resourceTable) listBooks(
+	ctx context.Context,
+	db orm.DB,
+	name string,
+	ordering []orderby.Field,
+	page pagination.Page,
+) ([]*entities.Book, error) {
+	var books []*Book
+	query := whereNotDeleted(db.ModelContext(ctx, &books).Relation("Shelf"), false).
+		Relation("Contributors", orderContributors).
+		Where(t.bookColumn+" = ?", name)
+
+	query, err := paginate(query, ordering, page, bookOrderColumns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to paginate %s books: %w", t.noun, err)
+	}
+
+	err = query.Select()
+	if err != nil {
+		return nil, fmt.Errorf("failed to select %s books in postgres: %w", t.noun, err)
+	}
+
+	eBooks := make([]*entities.Book, len(books))
+	for i, book := range books {
+		eBooks[i] = book.toEntity()
+	}
+
+	return eBooks, nil
+}
@@ -0,0 +1,236 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-pg/pg/v10/orm"
+
+	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/filter"
+	"github.com/Henrod/library/domain/orderby"
+	"github.com/Henrod/library/domain/pagination"
+)
+
+// Search queries join the books to the parsed search query, named search_query.
+const (
+	// bookRelevanceColumn ranks the match weighting title over author over description, as in search_vector.
+	// It is cast to double precision, so the page cursor has the exact value compared in the next page.
+	bookRelevanceColumn = "ts_rank(book.search_vector, search_query)::float8"
+	// bookSnippetText is the HTML escaped book text, so the highlights are the only tags of the snippet.
+	// Postgres parses the escaped characters as XML entities, which ts_headline keeps as they are.
+	bookSnippetText = "replace(replace(replace(replace(replace(" +
+		"concat_ws(' - ', book.title, book.author, book.description), " +
+		`'&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;')`
+	bookSnippetColumn = "ts_headline('english', " + bookSnippetText + ", " +
+		"search_query, 'StartSel=<b>, StopSel=</b>, MaxFragments=2, MaxWords=20, MinWords=5')"
+)
+
+// bookSearchOrderColumns maps the search sort fields to their columns.
+var bookSearchOrderColumns = map[string]string{
+	"relevance":  bookRelevanceColumn,
+	"shelf_name": "book.shelf_name",
+	"name":       "book.name",
+}
+
+// bookFacetColumns maps the facet fields to their columns, as text so every bucket value is a string.
+var bookFacetColumns = map[string]string{
+	"author":           "book.author",
+	"shelf":            "book.shelf_name",
+	"language":         "book.language",
+	"publication_year": "book.publication_year::text",
+}
+
+// bookSearchResult is a book selected with its relevance to the search query.
+type bookSearchResult struct {
+	Book      `pg:",inherit"`
+	Relevance float64
+	Snippet   string
+}
+
+func (r *bookSearchResult) toEntity() *entities.BookSearchResult {
+	return &entities.BookSearchResult{
+		Book:      r.Book.toEntity(),
+		Relevance: r.Relevance,
+		Snippet:   r.Snippet,
+	}
+}
+
+// bookFacetBucket is the number of books with a value of a facet.
+type bookFacetBucket struct {
+	Value string
+	Count int
+}
+
+// SearchBooks returns the books matching the web search query and filter, ignoring deleted books.
+func (g *Gateway) SearchBooks(
+	ctx context.Context,
+	query string,
+	expr filter.Expr,
+	ordering []orderby.Field,
+	page pagination.Page,
+) ([]*entities.BookSearchResult, error) {
+	var results []*bookSearchResult
+
+	err := selectSearchResults(g.db.ModelContext(ctx, &results), query, expr, ordering, page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search books: %w", err)
+	}
+
+	eResults := make([]*entities.BookSearchResult, len(results))
+	for i, result := range results {
+		eResults[i] = result.toEntity()
+	}
+
+	return eResults, nil
+}
+
+// SearchShelfBooks returns the books of shelf matching the web search query and filter, ignoring deleted books.
+func (g *Gateway) SearchShelfBooks(
+	ctx context.Context,
+	shelfName, query string,
+	expr filter.Expr,
+	ordering []orderby.Field,
+	page pagination.Page,
+) ([]*entities.BookSearchResult, error) {
+	var results []*bookSearchResult
+
+	err := selectSearchResults(
+		g.db.ModelContext(ctx, &results).Where("book.shelf_name = ?", shelfName),
+		query, expr, ordering, page,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search shelf books: %w", err)
+	}
+
+	eResults := make([]*entities.BookSearchResult, len(results))
+	for i, result := range results {
+		eResults[i] = result.toEntity()
+	}
+
+	return eResults, nil
+}
+
+// SearchBooksFacet counts the books matching the web search query and filter per value of field,
+// returning the limit most common values and ignoring deleted books.
+func (g *Gateway) SearchBooksFacet(
+	ctx context.Context,
+	query string,
+	expr filter.Expr,
+	field string,
+	limit int,
+) ([]*entities.FacetBucket, error) {
+	buckets, err := selectFacetBuckets(g.db.ModelContext(ctx, (*Book)(nil)), query, expr, field, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count books facet: %w", err)
+	}
+
+	return buckets, nil
+}
+
+// SearchShelfBooksFacet counts the books of shelf matching the web search query and filter per value of field,
+// returning the limit most common values and ignoring deleted books.
+func (g *Gateway) SearchShelfBooksFacet(
+	ctx context.Context,
+	shelfName, query string,
+	expr filter.Expr,
+	field string,
+	limit int,
+) ([]*entities.FacetBucket, error) {
+	buckets, err := selectFacetBuckets(
+		g.db.ModelContext(ctx, (*Book)(nil)).Where("book.shelf_name = ?", shelfName),
+		query, expr, field, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count shelf books facet: %w", err)
+	}
+
+	return buckets, nil
+}
+
+// selectSearchResults selects into the query model the page of books matching searchQuery and expr.
+// The search query follows the websearch_to_tsquery syntax, which never fails to parse.
+func selectSearchResults(
+	query *orm.Query,
+	searchQuery string,
+	expr filter.Expr,
+	ordering []orderby.Field,
+	page pagination.Page,
+) error {
+	query, err := whereSearch(query, searchQuery, expr)
+	if err != nil {
+		return err
+	}
+
+	query = query.
+		Relation("Contributors", orderContributors).
+		ColumnExpr("book.*").
+		ColumnExpr(bookRelevanceColumn + " AS relevance").
+		ColumnExpr(bookSnippetColumn + " AS snippet")
+
+	query, err = paginate(query, ordering, page, bookSearchOrderColumns)
+	if err != nil {
+		return fmt.Errorf("failed to paginate search results: %w", err)
+	}
+
+	if err := query.Select(); err != nil {
+		return fmt.Errorf("failed to select search results in postgres: %w", err)
+	}
+
+	return nil
+}
+
+// selectFacetBuckets counts the books matching searchQuery and expr per non-empty value of field,
+// from the most to the least common value and by value on ties.
+func selectFacetBuckets(
+	query *orm.Query,
+	searchQuery string,
+	expr filter.Expr,
+	field string,
+	limit int,
+) ([]*entities.FacetBucket, error) {
+	column, ok := bookFacetColumns[field]
+	if !ok {
+		return nil, fmt.Errorf("field %s has no facet column", field)
+	}
+
+	query, err := whereSearch(query, searchQuery, expr)
+	if err != nil {
+		return nil, err
+	}
+
+	var buckets []*bookFacetBucket
+
+	err = query.
+		ColumnExpr(column + " AS value").
+		ColumnExpr("count(*) AS count").
+		Where(column + " <> ''").
+		GroupExpr(column).
+		OrderExpr("count DESC, value").
+		Limit(limit).
+		Select(&buckets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select facet buckets in postgres: %w", err)
+	}
+
+	eBuckets := make([]*entities.FacetBucket, len(buckets))
+	for i, bucket := range buckets {
+		eBuckets[i] = &entities.FacetBucket{Value: bucket.Value, Count: bucket.Count}
+	}
+
+	return eBuckets, nil
+}
+
+// whereSearch joins the parsed searchQuery to the books, keeping the not deleted ones matching it and expr.
+func whereSearch(query *orm.Query, searchQuery string, expr filter.Expr) (*orm.Query, error) {
+	query = query.
+		TableExpr("websearch_to_tsquery('english', ?) AS search_query", searchQuery).
+		Where("book.search_vector @@ search_query")
+
+	query, err := whereFilter(whereNotDeleted(query, false), expr, bookFilterColumns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter search results: %w", err)
+	}
+
+	return query, nil
+}
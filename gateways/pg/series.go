@@ -0,0 +1,174 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+
+	"github.com/Henrod/library/domain/entities"
+	domainErrors "github.com/Henrod/library/domain/errors"
+	"github.com/Henrod/library/domain/filter"
+	"github.com/Henrod/library/domain/orderby"
+	"github.com/Henrod/library/domain/pagination"
+)
+
+// bookSeriesConstraint is the foreign key from the books to their series.
+const bookSeriesConstraint = "fk_series"
+
+// seriesTable has the series queries shared by the resources.
+var seriesTable = resourceTable{
+	noun:       "series",
+	primaryKey: "series_pkey",
+	columns:    namedColumns("series"),
+	bookColumn: "book.series_name",
+}
+
+type Series struct {
+	Name        string `pg:",pk"`
+	DisplayName string
+	VolumeCount int
+	CreateTime  time.Time
+	UpdateTime  time.Time
+}
+
+func (s *Series) toEntity() *entities.Series {
+	return &entities.Series{
+		Name:        s.Name,
+		DisplayName: s.DisplayName,
+		VolumeCount: s.VolumeCount,
+		CreateTime:  s.CreateTime,
+		UpdateTime:  s.UpdateTime,
+	}
+}
+
+// isSeriesViolation tells whether err is caused by a book in a series that doesn't exist,
+// or by removing a series that still has books.
+func isSeriesViolation(err error) bool {
+	var pgErr pg.Error
+
+	return errors.As(err, &pgErr) &&
+		pgErr.Field('C') == pgForeignKeyViolation &&
+		pgErr.Field('n') == bookSeriesConstraint
+}
+
+func (g *Gateway) ListSeries(
+	ctx context.Context,
+	expr filter.Expr,
+	ordering []orderby.Field,
+	page pagination.Page,
+) ([]*entities.Series, error) {
+	var series []*Series
+
+	err := seriesTable.list(g.db.ModelContext(ctx, &series), expr, ordering, page)
+	if err != nil {
+		return nil, err
+	}
+
+	eSeries := make([]*entities.Series, len(series))
+	for i, s := range series {
+		eSeries[i] = s.toEntity()
+	}
+
+	return eSeries, nil
+}
+
+// CreateSeries inserts the series.
+// If the series already exists, returns nil series and nil error.
+func (g *Gateway) CreateSeries(ctx context.Context, eSeries *entities.Series) (*entities.Series, error) {
+	now := time.Now()
+
+	series := &Series{
+		Name:        eSeries.Name,
+		DisplayName: eSeries.DisplayName,
+		VolumeCount: eSeries.VolumeCount,
+		CreateTime:  now,
+		UpdateTime:  now,
+	}
+
+	created, err := seriesTable.insert(g.db.ModelContext(ctx, series))
+	if !created {
+		return nil, err
+	}
+
+	return series.toEntity(), nil
+}
+
+// GetSeries returns the series of name.
+// If the series is not found, returns nil series and nil error.
+func (g *Gateway) GetSeries(ctx context.Context, seriesName string) (*entities.Series, error) {
+	series := &Series{Name: seriesName} //nolint:exhaustivestruct
+
+	found, err := seriesTable.get(g.db.ModelContext(ctx, series).WherePK())
+	if !found {
+		return nil, err
+	}
+
+	return series.toEntity(), nil
+}
+
+// UpdateSeries updates only the series columns in fields.
+// If the series is not found, returns nil series and nil error.
+func (g *Gateway) UpdateSeries(
+	ctx context.Context,
+	eSeries *entities.Series,
+	fields []string,
+) (*entities.Series, error) {
+	series := &Series{
+		Name:        eSeries.Name,
+		DisplayName: eSeries.DisplayName,
+		VolumeCount: eSeries.VolumeCount,
+		CreateTime:  time.Time{},
+		UpdateTime:  time.Now(),
+	}
+
+	found, err := seriesTable.update(g.db.ModelContext(ctx, series).WherePK(), fields)
+	if !found {
+		return nil, err
+	}
+
+	return series.toEntity(), nil
+}
+
+// DeleteSeries removes the series, returning false if it is not found.
+// If any book is still in the series, returns FailedPreconditionError.
+func (g *Gateway) DeleteSeries(ctx context.Context, seriesName string) (bool, error) {
+	series := &Series{Name: seriesName} //nolint:exhaustivestruct
+
+	deleted, err := seriesTable.delete(g.db.ModelContext(ctx, series).WherePK())
+	if isSeriesViolation(err) {
+		return false, domainErrors.FailedPreconditionError{
+			Details: fmt.Sprintf("series %s has books, remove them from the series first", seriesName),
+		}
+	}
+
+	return deleted, err
+}
+
+// ListSeriesBooks returns the page of not deleted books of the series, from every shelf.
+func (g *Gateway) ListSeriesBooks(
+	ctx context.Context,
+	seriesName string,
+	ordering []orderby.Field,
+	page pagination.Page,
+) ([]*entities.Book, error) {
+	return seriesTable.listBooks(ctx, g.db, seriesName, ordering, page)
+}
+
+// SeriesVolumeNumbers returns the distinct known volume numbers of the not deleted books of the series.
+func (g *Gateway) SeriesVolumeNumbers(ctx context.Context, seriesName string) ([]int, error) {
+	var volumeNumbers []int
+
+	err := whereNotDeleted(g.db.ModelContext(ctx, (*Book)(nil)), false).
+		ColumnExpr("DISTINCT book.volume_number").
+		Where("book.series_name = ?", seriesName).
+		Where("book.volume_number > 0").
+		Select(&volumeNumbers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select series volume numbers in postgres: %w", err)
+	}
+
+	return volumeNumbers, nil
+}
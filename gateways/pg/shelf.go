@@ -9,29 +9,68 @@ import (
 	"github.com/go-pg/pg/v10"
 
 	"github.com/Henrod/library/domain/entities"
+	"github.com/Henrod/library/domain/filter"
+	"github.com/Henrod/library/domain/orderby"
+	"github.com/Henrod/library/domain/pagination"
 )
 
 type Shelf struct {
-	Name       string `pg:",pk"`
-	CreateTime time.Time
-	UpdateTime time.Time
+	Name        string `pg:",pk"`
+	DisplayName string
+	Labels      map[string]string
+	CreateTime  time.Time
+	UpdateTime  time.Time
 }
 
 func (s *Shelf) toEntity() *entities.Shelf {
 	return &entities.Shelf{
-		Name:       s.Name,
-		CreateTime: s.CreateTime,
-		UpdateTime: s.UpdateTime,
+		Name:        s.Name,
+		DisplayName: s.DisplayName,
+		Labels:      s.Labels,
+		CreateTime:  s.CreateTime,
+		UpdateTime:  s.UpdateTime,
 	}
 }
 
+func (g *Gateway) ListShelves(
+	ctx context.Context,
+	expr filter.Expr,
+	ordering []orderby.Field,
+	page pagination.Page,
+) ([]*entities.Shelf, error) {
+	var shelves []*Shelf
+	query, err := whereFilter(g.db.ModelContext(ctx, &shelves), expr, shelfFilterColumns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter shelves: %w", err)
+	}
+
+	query, err = paginate(query, ordering, page, shelfOrderColumns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to paginate shelves: %w", err)
+	}
+
+	err = query.Select()
+	if err != nil {
+		return nil, fmt.Errorf("failed to select shelves in postgres: %w", err)
+	}
+
+	eShelves := make([]*entities.Shelf, len(shelves))
+	for i, shelf := range shelves {
+		eShelves[i] = shelf.toEntity()
+	}
+
+	return eShelves, nil
+}
+
 func (g *Gateway) CreateShelf(ctx context.Context, eShelf *entities.Shelf) (*entities.Shelf, error) {
 	now := time.Now()
 
 	shelf := &Shelf{
-		Name:       eShelf.Name,
-		CreateTime: now,
-		UpdateTime: now,
+		Name:        eShelf.Name,
+		DisplayName: eShelf.DisplayName,
+		Labels:      eShelf.Labels,
+		CreateTime:  now,
+		UpdateTime:  now,
 	}
 
 	_, err := g.db.ModelContext(ctx, shelf).Insert()
@@ -63,3 +102,48 @@ func (g *Gateway) GetShelf(ctx context.Context, shelfName string) (*entities.She
 
 	return shelf.toEntity(), nil
 }
+
+// UpdateShelf updates only the shelf columns in fields.
+// If shelf not found, returns nil shelf and nil error.
+func (g *Gateway) UpdateShelf(
+	ctx context.Context,
+	eShelf *entities.Shelf,
+	fields []string,
+) (*entities.Shelf, error) {
+	shelf := &Shelf{
+		Name:        eShelf.Name,
+		DisplayName: eShelf.DisplayName,
+		Labels:      eShelf.Labels,
+		CreateTime:  time.Time{},
+		UpdateTime:  time.Now(),
+	}
+
+	fields = append(fields, "update_time")
+
+	_, err := g.db.ModelContext(ctx, shelf).Column(fields...).WherePK().Returning("*").Update()
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to update shelf in postgres: %w", err)
+	}
+
+	return shelf.toEntity(), nil
+}
+
+func (g *Gateway) DeleteShelf(ctx context.Context, shelfName string) (bool, error) {
+	shelf := &Shelf{Name: shelfName} //nolint:exhaustivestruct
+	r, err := g.db.ModelContext(ctx, shelf).WherePK().Delete()
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRows) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to delete shelf in postgres: %w", err)
+	}
+
+	deleted := r.RowsAffected() > 0
+
+	return deleted, nil
+}
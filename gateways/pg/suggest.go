@@ -0,0 +1,111 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-pg/pg/v10"
+
+	"github.com/Henrod/library/domain/entities"
+)
+
+// suggestBooksQuery selects the titles and authors starting with the prefix (?0)
+// or with a word similar to it by pg_trgm (<%), both backed by the trigram indexes.
+// The similarity threshold of <% is set by setWordSimilarityThreshold in the same transaction.
+// The LIKE pattern (?1) is the escaped prefix followed by '%'.
+// If the shelf (?2) is NULL, books from every shelf are selected.
+const suggestBooksQuery = `
+SELECT text, field FROM (
+	SELECT DISTINCT book.title AS text, 'title' AS field
+	FROM books AS book
+	WHERE book.delete_time IS NULL
+		AND (?2::text IS NULL OR book.shelf_name = ?2)
+		AND (book.title ILIKE ?1 OR ?0 <% book.title)
+	UNION
+	SELECT DISTINCT book.author AS text, 'author' AS field
+	FROM books AS book
+	WHERE book.delete_time IS NULL
+		AND (?2::text IS NULL OR book.shelf_name = ?2)
+		AND (book.author ILIKE ?1 OR ?0 <% book.author)
+) AS candidates
+ORDER BY word_similarity(?0, text) DESC, text
+LIMIT ?3`
+
+// setWordSimilarityThreshold sets the minimum word_similarity of the <% operator until the transaction ends.
+const setWordSimilarityThreshold = "SET LOCAL pg_trgm.word_similarity_threshold = ?"
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type bookSuggestion struct {
+	Text  string
+	Field string
+}
+
+// SuggestBooks returns at most limit titles and authors with a word similar to prefix by at least minScore,
+// ignoring deleted books.
+func (g *Gateway) SuggestBooks(
+	ctx context.Context,
+	prefix string,
+	minScore float64,
+	limit int,
+) ([]*entities.BookSuggestion, error) {
+	suggestions, err := g.suggestBooks(ctx, nil, prefix, minScore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select book suggestions in postgres: %w", err)
+	}
+
+	return suggestions, nil
+}
+
+// SuggestShelfBooks returns at most limit titles and authors of shelf books with a word similar to prefix
+// by at least minScore, ignoring deleted books.
+func (g *Gateway) SuggestShelfBooks(
+	ctx context.Context,
+	shelfName, prefix string,
+	minScore float64,
+	limit int,
+) ([]*entities.BookSuggestion, error) {
+	suggestions, err := g.suggestBooks(ctx, shelfName, prefix, minScore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select shelf book suggestions in postgres: %w", err)
+	}
+
+	return suggestions, nil
+}
+
+// suggestBooks selects the suggestions from shelf, which is nil for every shelf.
+func (g *Gateway) suggestBooks(
+	ctx context.Context,
+	shelf interface{},
+	prefix string,
+	minScore float64,
+	limit int,
+) ([]*entities.BookSuggestion, error) {
+	var suggestions []*bookSuggestion
+
+	err := g.db.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		if _, err := tx.ExecContext(ctx, setWordSimilarityThreshold, minScore); err != nil {
+			return fmt.Errorf("failed to set word similarity threshold: %w", err)
+		}
+
+		_, err := tx.QueryContext(ctx, &suggestions, suggestBooksQuery,
+			prefix, likeEscaper.Replace(prefix)+"%", shelf, limit)
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	eSuggestions := make([]*entities.BookSuggestion, len(suggestions))
+	for i, suggestion := range suggestions {
+		eSuggestions[i] = &entities.BookSuggestion{
+			Text:  suggestion.Text,
+			Field: entities.SuggestionField(suggestion.Field),
+			Score: 0,
+		}
+	}
+
+	return eSuggestions, nil
+}
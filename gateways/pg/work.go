@@ -0,0 +1,154 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+
+	"github.com/Henrod/library/domain/entities"
+	domainErrors "github.com/Henrod/library/domain/errors"
+	"github.com/Henrod/library/domain/filter"
+	"github.com/Henrod/library/domain/orderby"
+	"github.com/Henrod/library/domain/pagination"
+)
+
+// bookWorkConstraint is the foreign key from the books to their works.
+const bookWorkConstraint = "fk_work"
+
+// workTable has the work queries shared by the resources.
+var workTable = resourceTable{
+	noun:       "work",
+	primaryKey: "works_pkey",
+	columns:    namedColumns("work"),
+	bookColumn: "book.work_name",
+}
+
+type Work struct {
+	Name        string `pg:",pk"`
+	DisplayName string
+	CreateTime  time.Time
+	UpdateTime  time.Time
+}
+
+func (w *Work) toEntity() *entities.Work {
+	return &entities.Work{
+		Name:        w.Name,
+		DisplayName: w.DisplayName,
+		CreateTime:  w.CreateTime,
+		UpdateTime:  w.UpdateTime,
+	}
+}
+
+// isWorkViolation tells whether err is caused by a book linked to a work that doesn't exist,
+// or by removing a work that still has books.
+func isWorkViolation(err error) bool {
+	var pgErr pg.Error
+
+	return errors.As(err, &pgErr) &&
+		pgErr.Field('C') == pgForeignKeyViolation &&
+		pgErr.Field('n') == bookWorkConstraint
+}
+
+func (g *Gateway) ListWorks(
+	ctx context.Context,
+	expr filter.Expr,
+	ordering []orderby.Field,
+	page pagination.Page,
+) ([]*entities.Work, error) {
+	var works []*Work
+
+	err := workTable.list(g.db.ModelContext(ctx, &works), expr, ordering, page)
+	if err != nil {
+		return nil, err
+	}
+
+	eWorks := make([]*entities.Work, len(works))
+	for i, work := range works {
+		eWorks[i] = work.toEntity()
+	}
+
+	return eWorks, nil
+}
+
+// CreateWork inserts the work.
+// If the work already exists, returns nil work and nil error.
+func (g *Gateway) CreateWork(ctx context.Context, eWork *entities.Work) (*entities.Work, error) {
+	now := time.Now()
+
+	work := &Work{
+		Name:        eWork.Name,
+		DisplayName: eWork.DisplayName,
+		CreateTime:  now,
+		UpdateTime:  now,
+	}
+
+	created, err := workTable.insert(g.db.ModelContext(ctx, work))
+	if !created {
+		return nil, err
+	}
+
+	return work.toEntity(), nil
+}
+
+// GetWork returns the work of name.
+// If the work is not found, returns nil work and nil error.
+func (g *Gateway) GetWork(ctx context.Context, workName string) (*entities.Work, error) {
+	work := &Work{Name: workName} //nolint:exhaustivestruct
+
+	found, err := workTable.get(g.db.ModelContext(ctx, work).WherePK())
+	if !found {
+		return nil, err
+	}
+
+	return work.toEntity(), nil
+}
+
+// UpdateWork updates only the work columns in fields.
+// If the work is not found, returns nil work and nil error.
+func (g *Gateway) UpdateWork(
+	ctx context.Context,
+	eWork *entities.Work,
+	fields []string,
+) (*entities.Work, error) {
+	work := &Work{
+		Name:        eWork.Name,
+		DisplayName: eWork.DisplayName,
+		CreateTime:  time.Time{},
+		UpdateTime:  time.Now(),
+	}
+
+	found, err := workTable.update(g.db.ModelContext(ctx, work).WherePK(), fields)
+	if !found {
+		return nil, err
+	}
+
+	return work.toEntity(), nil
+}
+
+// DeleteWork removes the work, returning false if it is not found.
+// If any book is still linked to the work, returns FailedPreconditionError.
+func (g *Gateway) DeleteWork(ctx context.Context, workName string) (bool, error) {
+	work := &Work{Name: workName} //nolint:exhaustivestruct
+
+	deleted, err := workTable.delete(g.db.ModelContext(ctx, work).WherePK())
+	if isWorkViolation(err) {
+		return false, domainErrors.FailedPreconditionError{
+			Details: fmt.Sprintf("work %s has books, link them to another work or unlink them first", workName),
+		}
+	}
+
+	return deleted, err
+}
+
+// ListWorkEditions returns the page of not deleted books linked to the work, from every shelf.
+func (g *Gateway) ListWorkEditions(
+	ctx context.Context,
+	workName string,
+	ordering []orderby.Field,
+	page pagination.Page,
+) ([]*entities.Book, error) {
+	return workTable.listBooks(ctx, g.db, workName, ordering, page)
+}
@@ -29,13 +29,97 @@ type LibraryServiceClient interface {
 	// Gets a book information.
 	GetBook(ctx context.Context, in *GetBookRequest, opts ...grpc.CallOption) (*Book, error)
 	// Creates a book in a shelf.
+	// The book ID is the request book_id, or generated by the server if empty.
 	CreateBook(ctx context.Context, in *CreateBookRequest, opts ...grpc.CallOption) (*Book, error)
 	// Updates a book's attribute of a shelf.
+	// If the book etag is set, the request is aborted when the book changed after the etag was read.
 	UpdateBook(ctx context.Context, in *UpdateBookRequest, opts ...grpc.CallOption) (*Book, error)
 	// Remove a book from the shelf.
+	// The book is soft deleted: it can be undeleted until its purge_time.
 	DeleteBook(ctx context.Context, in *DeleteBookRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Restores a deleted book that was not purged yet.
+	UndeleteBook(ctx context.Context, in *UndeleteBookRequest, opts ...grpc.CallOption) (*Book, error)
+	// Moves a book to another shelf, keeping its attributes and create_time.
+	MoveBook(ctx context.Context, in *MoveBookRequest, opts ...grpc.CallOption) (*Book, error)
+	// Gets many books of a shelf at once.
+	// If any book doesn't exist, the request fails with the index of the first missing book.
+	BatchGetBooks(ctx context.Context, in *BatchGetBooksRequest, opts ...grpc.CallOption) (*BatchGetBooksResponse, error)
+	// Creates many books in a shelf atomically, so either all or none of them are created.
+	// If any book is invalid, the request fails with the index of the first invalid book.
+	BatchCreateBooks(ctx context.Context, in *BatchCreateBooksRequest, opts ...grpc.CallOption) (*BatchCreateBooksResponse, error)
+	// Removes many books from a shelf atomically, so either all or none of them are removed.
+	// If any book doesn't exist, the request fails with the index of the first missing book.
+	BatchDeleteBooks(ctx context.Context, in *BatchDeleteBooksRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Searches books by relevance to a full-text query on their title, author and description.
+	// Use the parent "shelves/-" to search books from every shelf.
+	SearchBooks(ctx context.Context, in *SearchBooksRequest, opts ...grpc.CallOption) (*SearchBooksResponse, error)
+	// Suggests completions of titles and authors for what the user typed so far, tolerating typos.
+	// Use the parent "shelves/-" to suggest from every shelf.
+	SuggestBooks(ctx context.Context, in *SuggestBooksRequest, opts ...grpc.CallOption) (*SuggestBooksResponse, error)
+	// Lists the shelves in the library.
+	ListShelves(ctx context.Context, in *ListShelvesRequest, opts ...grpc.CallOption) (*ListShelvesResponse, error)
+	// Gets a shelf information.
+	GetShelf(ctx context.Context, in *GetShelfRequest, opts ...grpc.CallOption) (*Shelf, error)
 	// Starts a long running operation to create a shelf.
 	CreateShelf(ctx context.Context, in *CreateShelfRequest, opts ...grpc.CallOption) (*longrunning.Operation, error)
+	// Updates a shelf's attributes.
+	UpdateShelf(ctx context.Context, in *UpdateShelfRequest, opts ...grpc.CallOption) (*Shelf, error)
+	// Starts a long running operation to remove a shelf from the library.
+	// If the shelf still has books, force must be set to remove them as well.
+	DeleteShelf(ctx context.Context, in *DeleteShelfRequest, opts ...grpc.CallOption) (*longrunning.Operation, error)
+	// Lists the authors in the library.
+	ListAuthors(ctx context.Context, in *ListAuthorsRequest, opts ...grpc.CallOption) (*ListAuthorsResponse, error)
+	// Gets an author information.
+	GetAuthor(ctx context.Context, in *GetAuthorRequest, opts ...grpc.CallOption) (*Author, error)
+	// Creates an author, so books can be linked to it.
+	CreateAuthor(ctx context.Context, in *CreateAuthorRequest, opts ...grpc.CallOption) (*Author, error)
+	// Updates an author's attributes.
+	UpdateAuthor(ctx context.Context, in *UpdateAuthorRequest, opts ...grpc.CallOption) (*Author, error)
+	// Removes an author from the library.
+	// It fails if any book, even if deleted but not purged yet, is still linked to the author.
+	DeleteAuthor(ctx context.Context, in *DeleteAuthorRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Lists the books of an author across every shelf.
+	ListAuthorBooks(ctx context.Context, in *ListAuthorBooksRequest, opts ...grpc.CallOption) (*ListAuthorBooksResponse, error)
+	// Lists the series in the library.
+	ListSeries(ctx context.Context, in *ListSeriesRequest, opts ...grpc.CallOption) (*ListSeriesResponse, error)
+	// Gets a series information.
+	GetSeries(ctx context.Context, in *GetSeriesRequest, opts ...grpc.CallOption) (*Series, error)
+	// Creates a series, so books can be added to it as its volumes.
+	CreateSeries(ctx context.Context, in *CreateSeriesRequest, opts ...grpc.CallOption) (*Series, error)
+	// Updates a series' attributes.
+	UpdateSeries(ctx context.Context, in *UpdateSeriesRequest, opts ...grpc.CallOption) (*Series, error)
+	// Removes a series from the library.
+	// It fails if any book, even if deleted but not purged yet, is still in the series.
+	DeleteSeries(ctx context.Context, in *DeleteSeriesRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Lists the books of a series across every shelf in volume order, with the volumes missing in the library.
+	ListSeriesBooks(ctx context.Context, in *ListSeriesBooksRequest, opts ...grpc.CallOption) (*ListSeriesBooksResponse, error)
+	// Lists the works in the library.
+	ListWorks(ctx context.Context, in *ListWorksRequest, opts ...grpc.CallOption) (*ListWorksResponse, error)
+	// Gets a work information.
+	GetWork(ctx context.Context, in *GetWorkRequest, opts ...grpc.CallOption) (*Work, error)
+	// Creates a work, so books can be linked to it as its editions.
+	CreateWork(ctx context.Context, in *CreateWorkRequest, opts ...grpc.CallOption) (*Work, error)
+	// Updates a work's attributes.
+	UpdateWork(ctx context.Context, in *UpdateWorkRequest, opts ...grpc.CallOption) (*Work, error)
+	// Removes a work from the library.
+	// It fails if any book, even if deleted but not purged yet, is still linked to the work.
+	DeleteWork(ctx context.Context, in *DeleteWorkRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Lists the editions of a work across every shelf, which are the books linked to it.
+	ListWorkEditions(ctx context.Context, in *ListWorkEditionsRequest, opts ...grpc.CallOption) (*ListWorkEditionsResponse, error)
+	// Lists the physical copies of a book.
+	ListCopies(ctx context.Context, in *ListCopiesRequest, opts ...grpc.CallOption) (*ListCopiesResponse, error)
+	// Gets a copy information.
+	GetCopy(ctx context.Context, in *GetCopyRequest, opts ...grpc.CallOption) (*Copy, error)
+	// Adds a physical copy to a book.
+	// Returns ALREADY_EXISTS if another copy has the barcode, even a copy of a deleted book not purged yet.
+	CreateCopy(ctx context.Context, in *CreateCopyRequest, opts ...grpc.CallOption) (*Copy, error)
+	// Updates a copy's attributes, e.g. its status when it is lent.
+	UpdateCopy(ctx context.Context, in *UpdateCopyRequest, opts ...grpc.CallOption) (*Copy, error)
+	// Removes a copy from the library permanently, e.g. when it is discarded.
+	DeleteCopy(ctx context.Context, in *DeleteCopyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Finds the copy of any book with a barcode, as read by a scanner.
+	// Copies of deleted books are NOT_FOUND, though their barcodes stay taken until the books are purged.
+	LookupCopyByBarcode(ctx context.Context, in *LookupCopyByBarcodeRequest, opts ...grpc.CallOption) (*Copy, error)
 	// Gets the latest state of a long-running operation.  Clients can use this
 	// method to poll the operation result.
 	GetOperation(ctx context.Context, in *GetOperationRequest, opts ...grpc.CallOption) (*longrunning.Operation, error)
@@ -94,6 +178,87 @@ func (c *libraryServiceClient) DeleteBook(ctx context.Context, in *DeleteBookReq
 	return out, nil
 }
 
+func (c *libraryServiceClient) UndeleteBook(ctx context.Context, in *UndeleteBookRequest, opts ...grpc.CallOption) (*Book, error) {
+	out := new(Book)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/UndeleteBook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) MoveBook(ctx context.Context, in *MoveBookRequest, opts ...grpc.CallOption) (*Book, error) {
+	out := new(Book)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/MoveBook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) BatchGetBooks(ctx context.Context, in *BatchGetBooksRequest, opts ...grpc.CallOption) (*BatchGetBooksResponse, error) {
+	out := new(BatchGetBooksResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/BatchGetBooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) BatchCreateBooks(ctx context.Context, in *BatchCreateBooksRequest, opts ...grpc.CallOption) (*BatchCreateBooksResponse, error) {
+	out := new(BatchCreateBooksResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/BatchCreateBooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) BatchDeleteBooks(ctx context.Context, in *BatchDeleteBooksRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/BatchDeleteBooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) SearchBooks(ctx context.Context, in *SearchBooksRequest, opts ...grpc.CallOption) (*SearchBooksResponse, error) {
+	out := new(SearchBooksResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/SearchBooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) SuggestBooks(ctx context.Context, in *SuggestBooksRequest, opts ...grpc.CallOption) (*SuggestBooksResponse, error) {
+	out := new(SuggestBooksResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/SuggestBooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) ListShelves(ctx context.Context, in *ListShelvesRequest, opts ...grpc.CallOption) (*ListShelvesResponse, error) {
+	out := new(ListShelvesResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/ListShelves", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) GetShelf(ctx context.Context, in *GetShelfRequest, opts ...grpc.CallOption) (*Shelf, error) {
+	out := new(Shelf)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/GetShelf", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *libraryServiceClient) CreateShelf(ctx context.Context, in *CreateShelfRequest, opts ...grpc.CallOption) (*longrunning.Operation, error) {
 	out := new(longrunning.Operation)
 	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/CreateShelf", in, out, opts...)
@@ -103,6 +268,240 @@ func (c *libraryServiceClient) CreateShelf(ctx context.Context, in *CreateShelfR
 	return out, nil
 }
 
+func (c *libraryServiceClient) UpdateShelf(ctx context.Context, in *UpdateShelfRequest, opts ...grpc.CallOption) (*Shelf, error) {
+	out := new(Shelf)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/UpdateShelf", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) DeleteShelf(ctx context.Context, in *DeleteShelfRequest, opts ...grpc.CallOption) (*longrunning.Operation, error) {
+	out := new(longrunning.Operation)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/DeleteShelf", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) ListAuthors(ctx context.Context, in *ListAuthorsRequest, opts ...grpc.CallOption) (*ListAuthorsResponse, error) {
+	out := new(ListAuthorsResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/ListAuthors", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) GetAuthor(ctx context.Context, in *GetAuthorRequest, opts ...grpc.CallOption) (*Author, error) {
+	out := new(Author)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/GetAuthor", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) CreateAuthor(ctx context.Context, in *CreateAuthorRequest, opts ...grpc.CallOption) (*Author, error) {
+	out := new(Author)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/CreateAuthor", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) UpdateAuthor(ctx context.Context, in *UpdateAuthorRequest, opts ...grpc.CallOption) (*Author, error) {
+	out := new(Author)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/UpdateAuthor", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) DeleteAuthor(ctx context.Context, in *DeleteAuthorRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/DeleteAuthor", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) ListAuthorBooks(ctx context.Context, in *ListAuthorBooksRequest, opts ...grpc.CallOption) (*ListAuthorBooksResponse, error) {
+	out := new(ListAuthorBooksResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/ListAuthorBooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) ListSeries(ctx context.Context, in *ListSeriesRequest, opts ...grpc.CallOption) (*ListSeriesResponse, error) {
+	out := new(ListSeriesResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/ListSeries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) GetSeries(ctx context.Context, in *GetSeriesRequest, opts ...grpc.CallOption) (*Series, error) {
+	out := new(Series)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/GetSeries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) CreateSeries(ctx context.Context, in *CreateSeriesRequest, opts ...grpc.CallOption) (*Series, error) {
+	out := new(Series)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/CreateSeries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) UpdateSeries(ctx context.Context, in *UpdateSeriesRequest, opts ...grpc.CallOption) (*Series, error) {
+	out := new(Series)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/UpdateSeries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) DeleteSeries(ctx context.Context, in *DeleteSeriesRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/DeleteSeries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) ListSeriesBooks(ctx context.Context, in *ListSeriesBooksRequest, opts ...grpc.CallOption) (*ListSeriesBooksResponse, error) {
+	out := new(ListSeriesBooksResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/ListSeriesBooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) ListWorks(ctx context.Context, in *ListWorksRequest, opts ...grpc.CallOption) (*ListWorksResponse, error) {
+	out := new(ListWorksResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/ListWorks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) GetWork(ctx context.Context, in *GetWorkRequest, opts ...grpc.CallOption) (*Work, error) {
+	out := new(Work)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/GetWork", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) CreateWork(ctx context.Context, in *CreateWorkRequest, opts ...grpc.CallOption) (*Work, error) {
+	out := new(Work)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/CreateWork", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) UpdateWork(ctx context.Context, in *UpdateWorkRequest, opts ...grpc.CallOption) (*Work, error) {
+	out := new(Work)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/UpdateWork", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) DeleteWork(ctx context.Context, in *DeleteWorkRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/DeleteWork", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) ListWorkEditions(ctx context.Context, in *ListWorkEditionsRequest, opts ...grpc.CallOption) (*ListWorkEditionsResponse, error) {
+	out := new(ListWorkEditionsResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/ListWorkEditions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) ListCopies(ctx context.Context, in *ListCopiesRequest, opts ...grpc.CallOption) (*ListCopiesResponse, error) {
+	out := new(ListCopiesResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/ListCopies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) GetCopy(ctx context.Context, in *GetCopyRequest, opts ...grpc.CallOption) (*Copy, error) {
+	out := new(Copy)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/GetCopy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) CreateCopy(ctx context.Context, in *CreateCopyRequest, opts ...grpc.CallOption) (*Copy, error) {
+	out := new(Copy)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/CreateCopy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) UpdateCopy(ctx context.Context, in *UpdateCopyRequest, opts ...grpc.CallOption) (*Copy, error) {
+	out := new(Copy)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/UpdateCopy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) DeleteCopy(ctx context.Context, in *DeleteCopyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/DeleteCopy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryServiceClient) LookupCopyByBarcode(ctx context.Context, in *LookupCopyByBarcodeRequest, opts ...grpc.CallOption) (*Copy, error) {
+	out := new(Copy)
+	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/LookupCopyByBarcode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *libraryServiceClient) GetOperation(ctx context.Context, in *GetOperationRequest, opts ...grpc.CallOption) (*longrunning.Operation, error) {
 	out := new(longrunning.Operation)
 	err := c.cc.Invoke(ctx, "/api.v1.LibraryService/GetOperation", in, out, opts...)
@@ -121,13 +520,97 @@ type LibraryServiceServer interface {
 	// Gets a book information.
 	GetBook(context.Context, *GetBookRequest) (*Book, error)
 	// Creates a book in a shelf.
+	// The book ID is the request book_id, or generated by the server if empty.
 	CreateBook(context.Context, *CreateBookRequest) (*Book, error)
 	// Updates a book's attribute of a shelf.
+	// If the book etag is set, the request is aborted when the book changed after the etag was read.
 	UpdateBook(context.Context, *UpdateBookRequest) (*Book, error)
 	// Remove a book from the shelf.
+	// The book is soft deleted: it can be undeleted until its purge_time.
 	DeleteBook(context.Context, *DeleteBookRequest) (*emptypb.Empty, error)
+	// Restores a deleted book that was not purged yet.
+	UndeleteBook(context.Context, *UndeleteBookRequest) (*Book, error)
+	// Moves a book to another shelf, keeping its attributes and create_time.
+	MoveBook(context.Context, *MoveBookRequest) (*Book, error)
+	// Gets many books of a shelf at once.
+	// If any book doesn't exist, the request fails with the index of the first missing book.
+	BatchGetBooks(context.Context, *BatchGetBooksRequest) (*BatchGetBooksResponse, error)
+	// Creates many books in a shelf atomically, so either all or none of them are created.
+	// If any book is invalid, the request fails with the index of the first invalid book.
+	BatchCreateBooks(context.Context, *BatchCreateBooksRequest) (*BatchCreateBooksResponse, error)
+	// Removes many books from a shelf atomically, so either all or none of them are removed.
+	// If any book doesn't exist, the request fails with the index of the first missing book.
+	BatchDeleteBooks(context.Context, *BatchDeleteBooksRequest) (*emptypb.Empty, error)
+	// Searches books by relevance to a full-text query on their title, author and description.
+	// Use the parent "shelves/-" to search books from every shelf.
+	SearchBooks(context.Context, *SearchBooksRequest) (*SearchBooksResponse, error)
+	// Suggests completions of titles and authors for what the user typed so far, tolerating typos.
+	// Use the parent "shelves/-" to suggest from every shelf.
+	SuggestBooks(context.Context, *SuggestBooksRequest) (*SuggestBooksResponse, error)
+	// Lists the shelves in the library.
+	ListShelves(context.Context, *ListShelvesRequest) (*ListShelvesResponse, error)
+	// Gets a shelf information.
+	GetShelf(context.Context, *GetShelfRequest) (*Shelf, error)
 	// Starts a long running operation to create a shelf.
 	CreateShelf(context.Context, *CreateShelfRequest) (*longrunning.Operation, error)
+	// Updates a shelf's attributes.
+	UpdateShelf(context.Context, *UpdateShelfRequest) (*Shelf, error)
+	// Starts a long running operation to remove a shelf from the library.
+	// If the shelf still has books, force must be set to remove them as well.
+	DeleteShelf(context.Context, *DeleteShelfRequest) (*longrunning.Operation, error)
+	// Lists the authors in the library.
+	ListAuthors(context.Context, *ListAuthorsRequest) (*ListAuthorsResponse, error)
+	// Gets an author information.
+	GetAuthor(context.Context, *GetAuthorRequest) (*Author, error)
+	// Creates an author, so books can be linked to it.
+	CreateAuthor(context.Context, *CreateAuthorRequest) (*Author, error)
+	// Updates an author's attributes.
+	UpdateAuthor(context.Context, *UpdateAuthorRequest) (*Author, error)
+	// Removes an author from the library.
+	// It fails if any book, even if deleted but not purged yet, is still linked to the author.
+	DeleteAuthor(context.Context, *DeleteAuthorRequest) (*emptypb.Empty, error)
+	// Lists the books of an author across every shelf.
+	ListAuthorBooks(context.Context, *ListAuthorBooksRequest) (*ListAuthorBooksResponse, error)
+	// Lists the series in the library.
+	ListSeries(context.Context, *ListSeriesRequest) (*ListSeriesResponse, error)
+	// Gets a series information.
+	GetSeries(context.Context, *GetSeriesRequest) (*Series, error)
+	// Creates a series, so books can be added to it as its volumes.
+	CreateSeries(context.Context, *CreateSeriesRequest) (*Series, error)
+	// Updates a series' attributes.
+	UpdateSeries(context.Context, *UpdateSeriesRequest) (*Series, error)
+	// Removes a series from the library.
+	// It fails if any book, even if deleted but not purged yet, is still in the series.
+	DeleteSeries(context.Context, *DeleteSeriesRequest) (*emptypb.Empty, error)
+	// Lists the books of a series across every shelf in volume order, with the volumes missing in the library.
+	ListSeriesBooks(context.Context, *ListSeriesBooksRequest) (*ListSeriesBooksResponse, error)
+	// Lists the works in the library.
+	ListWorks(context.Context, *ListWorksRequest) (*ListWorksResponse, error)
+	// Gets a work information.
+	GetWork(context.Context, *GetWorkRequest) (*Work, error)
+	// Creates a work, so books can be linked to it as its editions.
+	CreateWork(context.Context, *CreateWorkRequest) (*Work, error)
+	// Updates a work's attributes.
+	UpdateWork(context.Context, *UpdateWorkRequest) (*Work, error)
+	// Removes a work from the library.
+	// It fails if any book, even if deleted but not purged yet, is still linked to the work.
+	DeleteWork(context.Context, *DeleteWorkRequest) (*emptypb.Empty, error)
+	// Lists the editions of a work across every shelf, which are the books linked to it.
+	ListWorkEditions(context.Context, *ListWorkEditionsRequest) (*ListWorkEditionsResponse, error)
+	// Lists the physical copies of a book.
+	ListCopies(context.Context, *ListCopiesRequest) (*ListCopiesResponse, error)
+	// Gets a copy information.
+	GetCopy(context.Context, *GetCopyRequest) (*Copy, error)
+	// Adds a physical copy to a book.
+	// Returns ALREADY_EXISTS if another copy has the barcode, even a copy of a deleted book not purged yet.
+	CreateCopy(context.Context, *CreateCopyRequest) (*Copy, error)
+	// Updates a copy's attributes, e.g. its status when it is lent.
+	UpdateCopy(context.Context, *UpdateCopyRequest) (*Copy, error)
+	// Removes a copy from the library permanently, e.g. when it is discarded.
+	DeleteCopy(context.Context, *DeleteCopyRequest) (*emptypb.Empty, error)
+	// Finds the copy of any book with a barcode, as read by a scanner.
+	// Copies of deleted books are NOT_FOUND, though their barcodes stay taken until the books are purged.
+	LookupCopyByBarcode(context.Context, *LookupCopyByBarcodeRequest) (*Copy, error)
 	// Gets the latest state of a long-running operation.  Clients can use this
 	// method to poll the operation result.
 	GetOperation(context.Context, *GetOperationRequest) (*longrunning.Operation, error)
@@ -152,9 +635,114 @@ func (UnimplementedLibraryServiceServer) UpdateBook(context.Context, *UpdateBook
 func (UnimplementedLibraryServiceServer) DeleteBook(context.Context, *DeleteBookRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteBook not implemented")
 }
+func (UnimplementedLibraryServiceServer) UndeleteBook(context.Context, *UndeleteBookRequest) (*Book, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndeleteBook not implemented")
+}
+func (UnimplementedLibraryServiceServer) MoveBook(context.Context, *MoveBookRequest) (*Book, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveBook not implemented")
+}
+func (UnimplementedLibraryServiceServer) BatchGetBooks(context.Context, *BatchGetBooksRequest) (*BatchGetBooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetBooks not implemented")
+}
+func (UnimplementedLibraryServiceServer) BatchCreateBooks(context.Context, *BatchCreateBooksRequest) (*BatchCreateBooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateBooks not implemented")
+}
+func (UnimplementedLibraryServiceServer) BatchDeleteBooks(context.Context, *BatchDeleteBooksRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeleteBooks not implemented")
+}
+func (UnimplementedLibraryServiceServer) SearchBooks(context.Context, *SearchBooksRequest) (*SearchBooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchBooks not implemented")
+}
+func (UnimplementedLibraryServiceServer) SuggestBooks(context.Context, *SuggestBooksRequest) (*SuggestBooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuggestBooks not implemented")
+}
+func (UnimplementedLibraryServiceServer) ListShelves(context.Context, *ListShelvesRequest) (*ListShelvesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShelves not implemented")
+}
+func (UnimplementedLibraryServiceServer) GetShelf(context.Context, *GetShelfRequest) (*Shelf, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShelf not implemented")
+}
 func (UnimplementedLibraryServiceServer) CreateShelf(context.Context, *CreateShelfRequest) (*longrunning.Operation, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateShelf not implemented")
 }
+func (UnimplementedLibraryServiceServer) UpdateShelf(context.Context, *UpdateShelfRequest) (*Shelf, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateShelf not implemented")
+}
+func (UnimplementedLibraryServiceServer) DeleteShelf(context.Context, *DeleteShelfRequest) (*longrunning.Operation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteShelf not implemented")
+}
+func (UnimplementedLibraryServiceServer) ListAuthors(context.Context, *ListAuthorsRequest) (*ListAuthorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuthors not implemented")
+}
+func (UnimplementedLibraryServiceServer) GetAuthor(context.Context, *GetAuthorRequest) (*Author, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuthor not implemented")
+}
+func (UnimplementedLibraryServiceServer) CreateAuthor(context.Context, *CreateAuthorRequest) (*Author, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAuthor not implemented")
+}
+func (UnimplementedLibraryServiceServer) UpdateAuthor(context.Context, *UpdateAuthorRequest) (*Author, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAuthor not implemented")
+}
+func (UnimplementedLibraryServiceServer) DeleteAuthor(context.Context, *DeleteAuthorRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAuthor not implemented")
+}
+func (UnimplementedLibraryServiceServer) ListAuthorBooks(context.Context, *ListAuthorBooksRequest) (*ListAuthorBooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuthorBooks not implemented")
+}
+func (UnimplementedLibraryServiceServer) ListSeries(context.Context, *ListSeriesRequest) (*ListSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSeries not implemented")
+}
+func (UnimplementedLibraryServiceServer) GetSeries(context.Context, *GetSeriesRequest) (*Series, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSeries not implemented")
+}
+func (UnimplementedLibraryServiceServer) CreateSeries(context.Context, *CreateSeriesRequest) (*Series, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSeries not implemented")
+}
+func (UnimplementedLibraryServiceServer) UpdateSeries(context.Context, *UpdateSeriesRequest) (*Series, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSeries not implemented")
+}
+func (UnimplementedLibraryServiceServer) DeleteSeries(context.Context, *DeleteSeriesRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSeries not implemented")
+}
+func (UnimplementedLibraryServiceServer) ListSeriesBooks(context.Context, *ListSeriesBooksRequest) (*ListSeriesBooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSeriesBooks not implemented")
+}
+func (UnimplementedLibraryServiceServer) ListWorks(context.Context, *ListWorksRequest) (*ListWorksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWorks not implemented")
+}
+func (UnimplementedLibraryServiceServer) GetWork(context.Context, *GetWorkRequest) (*Work, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWork not implemented")
+}
+func (UnimplementedLibraryServiceServer) CreateWork(context.Context, *CreateWorkRequest) (*Work, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWork not implemented")
+}
+func (UnimplementedLibraryServiceServer) UpdateWork(context.Context, *UpdateWorkRequest) (*Work, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWork not implemented")
+}
+func (UnimplementedLibraryServiceServer) DeleteWork(context.Context, *DeleteWorkRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWork not implemented")
+}
+func (UnimplementedLibraryServiceServer) ListWorkEditions(context.Context, *ListWorkEditionsRequest) (*ListWorkEditionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWorkEditions not implemented")
+}
+func (UnimplementedLibraryServiceServer) ListCopies(context.Context, *ListCopiesRequest) (*ListCopiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCopies not implemented")
+}
+func (UnimplementedLibraryServiceServer) GetCopy(context.Context, *GetCopyRequest) (*Copy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCopy not implemented")
+}
+func (UnimplementedLibraryServiceServer) CreateCopy(context.Context, *CreateCopyRequest) (*Copy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCopy not implemented")
+}
+func (UnimplementedLibraryServiceServer) UpdateCopy(context.Context, *UpdateCopyRequest) (*Copy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCopy not implemented")
+}
+func (UnimplementedLibraryServiceServer) DeleteCopy(context.Context, *DeleteCopyRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCopy not implemented")
+}
+func (UnimplementedLibraryServiceServer) LookupCopyByBarcode(context.Context, *LookupCopyByBarcodeRequest) (*Copy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LookupCopyByBarcode not implemented")
+}
 func (UnimplementedLibraryServiceServer) GetOperation(context.Context, *GetOperationRequest) (*longrunning.Operation, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOperation not implemented")
 }
@@ -260,20 +848,650 @@ func _LibraryService_DeleteBook_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LibraryService_CreateShelf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateShelfRequest)
+func _LibraryService_UndeleteBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeleteBookRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LibraryServiceServer).CreateShelf(ctx, in)
+		return srv.(LibraryServiceServer).UndeleteBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/UndeleteBook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).UndeleteBook(ctx, req.(*UndeleteBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_MoveBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).MoveBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/MoveBook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).MoveBook(ctx, req.(*MoveBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_BatchGetBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetBooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).BatchGetBooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/BatchGetBooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).BatchGetBooks(ctx, req.(*BatchGetBooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_BatchCreateBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateBooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).BatchCreateBooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/BatchCreateBooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).BatchCreateBooks(ctx, req.(*BatchCreateBooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_BatchDeleteBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteBooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).BatchDeleteBooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/BatchDeleteBooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).BatchDeleteBooks(ctx, req.(*BatchDeleteBooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_SearchBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchBooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).SearchBooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/SearchBooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).SearchBooks(ctx, req.(*SearchBooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_SuggestBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestBooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).SuggestBooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/SuggestBooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).SuggestBooks(ctx, req.(*SuggestBooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_ListShelves_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShelvesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).ListShelves(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/ListShelves",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).ListShelves(ctx, req.(*ListShelvesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_GetShelf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShelfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).GetShelf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/GetShelf",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).GetShelf(ctx, req.(*GetShelfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_CreateShelf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateShelfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).CreateShelf(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
 		FullMethod: "/api.v1.LibraryService/CreateShelf",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LibraryServiceServer).CreateShelf(ctx, req.(*CreateShelfRequest))
+		return srv.(LibraryServiceServer).CreateShelf(ctx, req.(*CreateShelfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_UpdateShelf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateShelfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).UpdateShelf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/UpdateShelf",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).UpdateShelf(ctx, req.(*UpdateShelfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_DeleteShelf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteShelfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).DeleteShelf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/DeleteShelf",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).DeleteShelf(ctx, req.(*DeleteShelfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_ListAuthors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuthorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).ListAuthors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/ListAuthors",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).ListAuthors(ctx, req.(*ListAuthorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_GetAuthor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAuthorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).GetAuthor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/GetAuthor",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).GetAuthor(ctx, req.(*GetAuthorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_CreateAuthor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAuthorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).CreateAuthor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/CreateAuthor",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).CreateAuthor(ctx, req.(*CreateAuthorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_UpdateAuthor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAuthorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).UpdateAuthor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/UpdateAuthor",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).UpdateAuthor(ctx, req.(*UpdateAuthorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_DeleteAuthor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAuthorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).DeleteAuthor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/DeleteAuthor",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).DeleteAuthor(ctx, req.(*DeleteAuthorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_ListAuthorBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuthorBooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).ListAuthorBooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/ListAuthorBooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).ListAuthorBooks(ctx, req.(*ListAuthorBooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_ListSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).ListSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/ListSeries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).ListSeries(ctx, req.(*ListSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_GetSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).GetSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/GetSeries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).GetSeries(ctx, req.(*GetSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_CreateSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).CreateSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/CreateSeries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).CreateSeries(ctx, req.(*CreateSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_UpdateSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).UpdateSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/UpdateSeries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).UpdateSeries(ctx, req.(*UpdateSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_DeleteSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).DeleteSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/DeleteSeries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).DeleteSeries(ctx, req.(*DeleteSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_ListSeriesBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSeriesBooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).ListSeriesBooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/ListSeriesBooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).ListSeriesBooks(ctx, req.(*ListSeriesBooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_ListWorks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWorksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).ListWorks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/ListWorks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).ListWorks(ctx, req.(*ListWorksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_GetWork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWorkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).GetWork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/GetWork",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).GetWork(ctx, req.(*GetWorkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_CreateWork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWorkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).CreateWork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/CreateWork",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).CreateWork(ctx, req.(*CreateWorkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_UpdateWork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWorkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).UpdateWork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/UpdateWork",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).UpdateWork(ctx, req.(*UpdateWorkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_DeleteWork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWorkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).DeleteWork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/DeleteWork",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).DeleteWork(ctx, req.(*DeleteWorkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_ListWorkEditions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWorkEditionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).ListWorkEditions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/ListWorkEditions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).ListWorkEditions(ctx, req.(*ListWorkEditionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_ListCopies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCopiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).ListCopies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/ListCopies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).ListCopies(ctx, req.(*ListCopiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_GetCopy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCopyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).GetCopy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/GetCopy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).GetCopy(ctx, req.(*GetCopyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_CreateCopy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCopyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).CreateCopy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/CreateCopy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).CreateCopy(ctx, req.(*CreateCopyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_UpdateCopy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCopyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).UpdateCopy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/UpdateCopy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).UpdateCopy(ctx, req.(*UpdateCopyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_DeleteCopy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCopyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).DeleteCopy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/DeleteCopy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).DeleteCopy(ctx, req.(*DeleteCopyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LibraryService_LookupCopyByBarcode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupCopyByBarcodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LibraryServiceServer).LookupCopyByBarcode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.LibraryService/LookupCopyByBarcode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LibraryServiceServer).LookupCopyByBarcode(ctx, req.(*LookupCopyByBarcodeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -323,10 +1541,150 @@ var LibraryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteBook",
 			Handler:    _LibraryService_DeleteBook_Handler,
 		},
+		{
+			MethodName: "UndeleteBook",
+			Handler:    _LibraryService_UndeleteBook_Handler,
+		},
+		{
+			MethodName: "MoveBook",
+			Handler:    _LibraryService_MoveBook_Handler,
+		},
+		{
+			MethodName: "BatchGetBooks",
+			Handler:    _LibraryService_BatchGetBooks_Handler,
+		},
+		{
+			MethodName: "BatchCreateBooks",
+			Handler:    _LibraryService_BatchCreateBooks_Handler,
+		},
+		{
+			MethodName: "BatchDeleteBooks",
+			Handler:    _LibraryService_BatchDeleteBooks_Handler,
+		},
+		{
+			MethodName: "SearchBooks",
+			Handler:    _LibraryService_SearchBooks_Handler,
+		},
+		{
+			MethodName: "SuggestBooks",
+			Handler:    _LibraryService_SuggestBooks_Handler,
+		},
+		{
+			MethodName: "ListShelves",
+			Handler:    _LibraryService_ListShelves_Handler,
+		},
+		{
+			MethodName: "GetShelf",
+			Handler:    _LibraryService_GetShelf_Handler,
+		},
 		{
 			MethodName: "CreateShelf",
 			Handler:    _LibraryService_CreateShelf_Handler,
 		},
+		{
+			MethodName: "UpdateShelf",
+			Handler:    _LibraryService_UpdateShelf_Handler,
+		},
+		{
+			MethodName: "DeleteShelf",
+			Handler:    _LibraryService_DeleteShelf_Handler,
+		},
+		{
+			MethodName: "ListAuthors",
+			Handler:    _LibraryService_ListAuthors_Handler,
+		},
+		{
+			MethodName: "GetAuthor",
+			Handler:    _LibraryService_GetAuthor_Handler,
+		},
+		{
+			MethodName: "CreateAuthor",
+			Handler:    _LibraryService_CreateAuthor_Handler,
+		},
+		{
+			MethodName: "UpdateAuthor",
+			Handler:    _LibraryService_UpdateAuthor_Handler,
+		},
+		{
+			MethodName: "DeleteAuthor",
+			Handler:    _LibraryService_DeleteAuthor_Handler,
+		},
+		{
+			MethodName: "ListAuthorBooks",
+			Handler:    _LibraryService_ListAuthorBooks_Handler,
+		},
+		{
+			MethodName: "ListSeries",
+			Handler:    _LibraryService_ListSeries_Handler,
+		},
+		{
+			MethodName: "GetSeries",
+			Handler:    _LibraryService_GetSeries_Handler,
+		},
+		{
+			MethodName: "CreateSeries",
+			Handler:    _LibraryService_CreateSeries_Handler,
+		},
+		{
+			MethodName: "UpdateSeries",
+			Handler:    _LibraryService_UpdateSeries_Handler,
+		},
+		{
+			MethodName: "DeleteSeries",
+			Handler:    _LibraryService_DeleteSeries_Handler,
+		},
+		{
+			MethodName: "ListSeriesBooks",
+			Handler:    _LibraryService_ListSeriesBooks_Handler,
+		},
+		{
+			MethodName: "ListWorks",
+			Handler:    _LibraryService_ListWorks_Handler,
+		},
+		{
+			MethodName: "GetWork",
+			Handler:    _LibraryService_GetWork_Handler,
+		},
+		{
+			MethodName: "CreateWork",
+			Handler:    _LibraryService_CreateWork_Handler,
+		},
+		{
+			MethodName: "UpdateWork",
+			Handler:    _LibraryService_UpdateWork_Handler,
+		},
+		{
+			MethodName: "DeleteWork",
+			Handler:    _LibraryService_DeleteWork_Handler,
+		},
+		{
+			MethodName: "ListWorkEditions",
+			Handler:    _LibraryService_ListWorkEditions_Handler,
+		},
+		{
+			MethodName: "ListCopies",
+			Handler:    _LibraryService_ListCopies_Handler,
+		},
+		{
+			MethodName: "GetCopy",
+			Handler:    _LibraryService_GetCopy_Handler,
+		},
+		{
+			MethodName: "CreateCopy",
+			Handler:    _LibraryService_CreateCopy_Handler,
+		},
+		{
+			MethodName: "UpdateCopy",
+			Handler:    _LibraryService_UpdateCopy_Handler,
+		},
+		{
+			MethodName: "DeleteCopy",
+			Handler:    _LibraryService_DeleteCopy_Handler,
+		},
+		{
+			MethodName: "LookupCopyByBarcode",
+			Handler:    _LibraryService_LookupCopyByBarcode_Handler,
+		},
 		{
 			MethodName: "GetOperation",
 			Handler:    _LibraryService_GetOperation_Handler,
@@ -2,6 +2,7 @@ package api
 
 import (
 	"errors"
+	"strconv"
 
 	domainErrors "github.com/Henrod/library/domain/errors"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -15,6 +16,8 @@ import (
 
 type Details map[codes.Code][]proto.Message
 
+// GRPCError returns the gRPC status of the domain error with the details of its code.
+// The field violation of a BadRequestError and the index of a BatchItemError are always added to the details.
 func GRPCError(err error, details Details) error {
 	if err == nil {
 		return nil
@@ -22,6 +25,16 @@ func GRPCError(err error, details Details) error {
 
 	var grpcError error
 
+	if badRequestDetail, ok := BadRequestDetails(err); ok {
+		details = withDetail(details, badRequestDetail, codes.InvalidArgument)
+	}
+
+	if batchItemDetail, ok := batchItemDetails(err); ok {
+		details = withDetail(details, batchItemDetail,
+			codes.NotFound, codes.AlreadyExists, codes.InvalidArgument, codes.FailedPrecondition, codes.Aborted,
+		)
+	}
+
 	notFoundError := new(domainErrors.NotFoundError)
 	if errors.As(err, notFoundError) {
 		grpcError = withDetails(codes.NotFound, "resource not found", details[codes.NotFound]...)
@@ -32,11 +45,21 @@ func GRPCError(err error, details Details) error {
 		grpcError = withDetails(codes.AlreadyExists, "resource already exists", details[codes.AlreadyExists]...)
 	}
 
-	badRequestError := new(domainErrors.BadRequestError)
-	if errors.As(err, badRequestError) {
+	var badRequestError *domainErrors.BadRequestError
+	if errors.As(err, &badRequestError) {
 		grpcError = withDetails(codes.InvalidArgument, "invalid argument", details[codes.InvalidArgument]...)
 	}
 
+	failedPreconditionError := new(domainErrors.FailedPreconditionError)
+	if errors.As(err, failedPreconditionError) {
+		grpcError = withDetails(codes.FailedPrecondition, "failed precondition", details[codes.FailedPrecondition]...)
+	}
+
+	abortedError := new(domainErrors.AbortedError)
+	if errors.As(err, abortedError) {
+		grpcError = withDetails(codes.Aborted, "aborted", details[codes.Aborted]...)
+	}
+
 	if grpcError == nil {
 		grpcError = status.Errorf(codes.Internal, "internal error")
 	}
@@ -64,6 +87,53 @@ func BadRequestDetails(err error) (*errdetails.BadRequest, bool) {
 	}, true
 }
 
+// InvalidArgument returns the INVALID_ARGUMENT error of a BadRequestError, with its field violation as details.
+func InvalidArgument(err error) error {
+	return GRPCError(err, nil)
+}
+
+// AbortedDetails returns the reason why the request was aborted.
+func AbortedDetails(err error) (*errdetails.ErrorInfo, bool) {
+	abortedError := new(domainErrors.AbortedError)
+	if !errors.As(err, abortedError) {
+		return nil, false
+	}
+
+	return &errdetails.ErrorInfo{
+		Reason:   abortedError.Reason,
+		Domain:   "library",
+		Metadata: map[string]string{"description": abortedError.Details},
+	}, true
+}
+
+// batchItemDetails identifies the item of a batch request that failed the batch.
+func batchItemDetails(err error) (*errdetails.ErrorInfo, bool) {
+	batchItemError := new(domainErrors.BatchItemError)
+	if !errors.As(err, batchItemError) {
+		return nil, false
+	}
+
+	return &errdetails.ErrorInfo{
+		Reason:   "BATCH_ITEM_FAILED",
+		Domain:   "library",
+		Metadata: map[string]string{"index": strconv.Itoa(batchItemError.Index)},
+	}, true
+}
+
+// withDetail adds detail to the details of every code, without modifying details.
+func withDetail(details Details, detail proto.Message, detailCodes ...codes.Code) Details {
+	result := Details{}
+	for code, messages := range details {
+		result[code] = messages
+	}
+
+	for _, code := range detailCodes {
+		result[code] = append(append([]proto.Message{}, result[code]...), detail)
+	}
+
+	return result
+}
+
 func withDetails(code codes.Code, msg string, details ...proto.Message) error {
 	errStatus := status.New(code, msg)
 	detailedStatus, err := errStatus.WithDetails(details...)
@@ -0,0 +1,63 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	domainErrors "github.com/Henrod/library/domain/errors"
+	"github.com/Henrod/library/domain/resourcename"
+	"github.com/Henrod/library/service/api"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func invalidBatchItem(field, description string) error {
+	errStatus, _ := status.New(codes.InvalidArgument, "invalid batch item").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+			Field:       field,
+			Description: description,
+		}},
+	})
+
+	return errStatus.Err() //nolint:wrapcheck
+}
+
+// batchBookNames returns the shelf of parent and the book of each name,
+// which must be a book resource name from that shelf.
+func batchBookNames(parent string, names []string) (shelfName string, bookNames []string, err error) {
+	parentName, err := resourcename.ParseShelf("parent", parent)
+	if err != nil {
+		return "", nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	bookNames = make([]string, len(names))
+
+	for i, name := range names {
+		bookName, err := resourcename.ParseBook(fmt.Sprintf("names[%d]", i), name)
+		if err != nil {
+			return "", nil, api.InvalidArgument(err) //nolint:wrapcheck
+		}
+
+		if bookName.Shelf != parentName.Shelf {
+			return "", nil, invalidBatchItem(
+				fmt.Sprintf("names[%d]", i),
+				"book must be from the parent shelf",
+			)
+		}
+
+		bookNames[i] = bookName.Book
+	}
+
+	return parentName.Shelf, bookNames, nil
+}
+
+// batchItemName returns the name of the batch item that failed with err, if any.
+func batchItemName(names []string, err error) string {
+	var batchItemError domainErrors.BatchItemError
+	if !errors.As(err, &batchItemError) || batchItemError.Index >= len(names) {
+		return ""
+	}
+
+	return names[batchItemError.Index]
+}
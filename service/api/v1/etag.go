@@ -0,0 +1,47 @@
+package v1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func errInvalidETag(field string) error {
+	errStatus, _ := status.New(codes.InvalidArgument, "invalid etag").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+			Field:       field,
+			Description: fmt.Sprintf("%s must be an etag returned by the server", field),
+		}},
+	})
+
+	return errStatus.Err() //nolint:wrapcheck
+}
+
+// toETag returns the etag of a resource version, quoted as in https://datatracker.ietf.org/doc/html/rfc7232.
+func toETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// fromETag returns the resource version of etag, or zero if etag is empty.
+// Weak etags are accepted, as the version is the same.
+func fromETag(field, etag string) (int64, error) {
+	if etag == "" {
+		return 0, nil
+	}
+
+	unquoted, err := strconv.Unquote(strings.TrimPrefix(etag, "W/"))
+	if err != nil {
+		return 0, errInvalidETag(field)
+	}
+
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, errInvalidETag(field)
+	}
+
+	return version, nil
+}
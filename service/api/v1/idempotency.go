@@ -0,0 +1,90 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/Henrod/library/service/api"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	protov2 "google.golang.org/protobuf/proto"
+
+	"github.com/golang/protobuf/proto" //nolint:staticcheck
+)
+
+// idempotent runs execute once per request ID and sets its result in response.
+// Retries with the same request ID set the original response instead, see https://google.aip.dev/155.
+// The request hash ignores the request_id field, so it identifies the request content.
+func (l *LibraryService) idempotent(
+	ctx context.Context,
+	method, requestID string,
+	request, response protov2.Message,
+	execute func(ctx context.Context) (protov2.Message, error),
+) error {
+	hash, err := idempotencyHash(method, request)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to hash request")
+
+		return status.Errorf(codes.Internal, "internal error") //nolint:wrapcheck
+	}
+
+	var executeErr error
+
+	serialized, err := l.idempotency.Execute(ctx, requestID, hash, func(ctx context.Context) ([]byte, error) {
+		var message protov2.Message
+
+		message, executeErr = execute(ctx)
+		if executeErr != nil {
+			return nil, executeErr
+		}
+
+		serialized, err := protov2.MarshalOptions{Deterministic: true}.Marshal(message)
+		if err != nil {
+			l.log.With(zap.Error(err)).Error("failed to serialize response")
+			executeErr = status.Errorf(codes.Internal, "internal error")
+
+			return nil, executeErr
+		}
+
+		return serialized, nil
+	})
+	if err != nil && executeErr != nil {
+		return executeErr
+	}
+
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to execute idempotent request in domain")
+
+		details := api.Details{}
+		if abortedDetail, ok := api.AbortedDetails(err); ok {
+			details[codes.Aborted] = []proto.Message{abortedDetail}
+		}
+
+		return api.GRPCError(err, details) //nolint:wrapcheck
+	}
+
+	if err := protov2.Unmarshal(serialized, response); err != nil {
+		l.log.With(zap.Error(err)).Error("failed to deserialize response")
+
+		return status.Errorf(codes.Internal, "internal error") //nolint:wrapcheck
+	}
+
+	return nil
+}
+
+// idempotencyHash identifies the request of method, except its request_id field.
+func idempotencyHash(method string, request protov2.Message) ([]byte, error) {
+	request = protov2.Clone(request)
+
+	reflection := request.ProtoReflect()
+	if field := reflection.Descriptor().Fields().ByName("request_id"); field != nil {
+		reflection.Clear(field)
+	}
+
+	serialized, err := protov2.MarshalOptions{Deterministic: true}.Marshal(request)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return requestHash(method, string(serialized)), nil
+}
@@ -2,13 +2,22 @@ package v1
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
+	"strconv"
 	"time"
 
+	"github.com/Henrod/library/domain/authors"
 	"github.com/Henrod/library/domain/books"
+	"github.com/Henrod/library/domain/copies"
 	"github.com/Henrod/library/domain/entities"
+	domainErrors "github.com/Henrod/library/domain/errors"
+	"github.com/Henrod/library/domain/idempotency"
+	"github.com/Henrod/library/domain/pagination"
+	"github.com/Henrod/library/domain/resourcename"
+	"github.com/Henrod/library/domain/series"
 	"github.com/Henrod/library/domain/shelves"
+	"github.com/Henrod/library/domain/works"
 	v1 "github.com/Henrod/library/protogen/go/api/v1"
 	"github.com/Henrod/library/service/api"
 	"go.uber.org/zap"
@@ -16,6 +25,7 @@ import (
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	protov2 "google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -25,71 +35,225 @@ import (
 )
 
 type LibraryService struct {
-	listBooks   *books.ListBooksDomain
-	getBook     *books.GetBookDomain
-	createBook  *books.CreateBookDomain
-	updateBook  *books.UpdateBookDomain
-	deleteBook  *books.DeleteBookDomain
-	getShelf    *shelves.GetShelfDomain
-	createShelf *shelves.CreateShelfDomain
-	log         *zap.SugaredLogger
+	listBooks    *books.ListBooksDomain
+	getBook      *books.GetBookDomain
+	createBook   *books.CreateBookDomain
+	updateBook   *books.UpdateBookDomain
+	deleteBook   *books.DeleteBookDomain
+	undeleteBook *books.UndeleteBookDomain
+	moveBook     *books.MoveBookDomain
+	listShelves  *shelves.ListShelvesDomain
+	getShelf     *shelves.GetShelfDomain
+	createShelf  *shelves.CreateShelfDomain
+	updateShelf  *shelves.UpdateShelfDomain
+	deleteShelf  *shelves.DeleteShelfDomain
+	pageTokens   *pageTokens
+	log          *zap.SugaredLogger
+
+	batchGetBooks    *books.BatchGetBooksDomain
+	batchCreateBooks *books.BatchCreateBooksDomain
+	batchDeleteBooks *books.BatchDeleteBooksDomain
+	searchBooks      *books.SearchBooksDomain
+	suggestBooks     *books.SuggestBooksDomain
+	idempotency      *idempotency.IdempotencyDomain
+
+	listAuthors     *authors.ListAuthorsDomain
+	getAuthor       *authors.GetAuthorDomain
+	createAuthor    *authors.CreateAuthorDomain
+	updateAuthor    *authors.UpdateAuthorDomain
+	deleteAuthor    *authors.DeleteAuthorDomain
+	listAuthorBooks *books.ListAuthorBooksDomain
+
+	listSeries      *series.ListSeriesDomain
+	getSeries       *series.GetSeriesDomain
+	createSeries    *series.CreateSeriesDomain
+	updateSeries    *series.UpdateSeriesDomain
+	deleteSeries    *series.DeleteSeriesDomain
+	listSeriesBooks *books.ListSeriesBooksDomain
+
+	listWorks        *works.ListWorksDomain
+	getWork          *works.GetWorkDomain
+	createWork       *works.CreateWorkDomain
+	updateWork       *works.UpdateWorkDomain
+	deleteWork       *works.DeleteWorkDomain
+	listWorkEditions *books.ListWorkEditionsDomain
+
+	listCopies          *copies.ListCopiesDomain
+	getCopy             *copies.GetCopyDomain
+	createCopy          *copies.CreateCopyDomain
+	updateCopy          *copies.UpdateCopyDomain
+	deleteCopy          *copies.DeleteCopyDomain
+	lookupCopyByBarcode *copies.LookupCopyByBarcodeDomain
 }
 
+// NewLibraryService returns the LibraryService.
+// The pageTokenKey signs page tokens, so it must be secret and shared by every server instance.
 func NewLibraryService(
 	log *zap.SugaredLogger,
+	pageTokenKey []byte,
 	listBooks *books.ListBooksDomain,
 	getBook *books.GetBookDomain,
 	createBook *books.CreateBookDomain,
 	updateBook *books.UpdateBookDomain,
 	deleteBook *books.DeleteBookDomain,
+	undeleteBook *books.UndeleteBookDomain,
+	moveBook *books.MoveBookDomain,
+	batchGetBooks *books.BatchGetBooksDomain,
+	batchCreateBooks *books.BatchCreateBooksDomain,
+	batchDeleteBooks *books.BatchDeleteBooksDomain,
+	searchBooks *books.SearchBooksDomain,
+	suggestBooks *books.SuggestBooksDomain,
+	listShelves *shelves.ListShelvesDomain,
 	getShelf *shelves.GetShelfDomain,
 	createShelf *shelves.CreateShelfDomain,
+	updateShelf *shelves.UpdateShelfDomain,
+	deleteShelf *shelves.DeleteShelfDomain,
+	idempotency *idempotency.IdempotencyDomain,
+	listAuthors *authors.ListAuthorsDomain,
+	getAuthor *authors.GetAuthorDomain,
+	createAuthor *authors.CreateAuthorDomain,
+	updateAuthor *authors.UpdateAuthorDomain,
+	deleteAuthor *authors.DeleteAuthorDomain,
+	listAuthorBooks *books.ListAuthorBooksDomain,
+	listSeries *series.ListSeriesDomain,
+	getSeries *series.GetSeriesDomain,
+	createSeries *series.CreateSeriesDomain,
+	updateSeries *series.UpdateSeriesDomain,
+	deleteSeries *series.DeleteSeriesDomain,
+	listSeriesBooks *books.ListSeriesBooksDomain,
+	listWorks *works.ListWorksDomain,
+	getWork *works.GetWorkDomain,
+	createWork *works.CreateWorkDomain,
+	updateWork *works.UpdateWorkDomain,
+	deleteWork *works.DeleteWorkDomain,
+	listWorkEditions *books.ListWorkEditionsDomain,
+	listCopies *copies.ListCopiesDomain,
+	getCopy *copies.GetCopyDomain,
+	createCopy *copies.CreateCopyDomain,
+	updateCopy *copies.UpdateCopyDomain,
+	deleteCopy *copies.DeleteCopyDomain,
+	lookupCopyByBarcode *copies.LookupCopyByBarcodeDomain,
 ) *LibraryService {
 	return &LibraryService{
-		log:         log,
-		listBooks:   listBooks,
-		getBook:     getBook,
-		createBook:  createBook,
-		updateBook:  updateBook,
-		deleteBook:  deleteBook,
-		getShelf:    getShelf,
-		createShelf: createShelf,
+		log:          log,
+		pageTokens:   &pageTokens{key: pageTokenKey, log: log},
+		listBooks:    listBooks,
+		getBook:      getBook,
+		createBook:   createBook,
+		updateBook:   updateBook,
+		deleteBook:   deleteBook,
+		undeleteBook: undeleteBook,
+		moveBook:     moveBook,
+		listShelves:  listShelves,
+		getShelf:     getShelf,
+		createShelf:  createShelf,
+		updateShelf:  updateShelf,
+		deleteShelf:  deleteShelf,
+
+		batchGetBooks:    batchGetBooks,
+		batchCreateBooks: batchCreateBooks,
+		batchDeleteBooks: batchDeleteBooks,
+		searchBooks:      searchBooks,
+		suggestBooks:     suggestBooks,
+		idempotency:      idempotency,
+
+		listAuthors:     listAuthors,
+		getAuthor:       getAuthor,
+		createAuthor:    createAuthor,
+		updateAuthor:    updateAuthor,
+		deleteAuthor:    deleteAuthor,
+		listAuthorBooks: listAuthorBooks,
+
+		listSeries:      listSeries,
+		getSeries:       getSeries,
+		createSeries:    createSeries,
+		updateSeries:    updateSeries,
+		deleteSeries:    deleteSeries,
+		listSeriesBooks: listSeriesBooks,
+
+		listWorks:        listWorks,
+		getWork:          getWork,
+		createWork:       createWork,
+		updateWork:       updateWork,
+		deleteWork:       deleteWork,
+		listWorkEditions: listWorkEditions,
+
+		listCopies:          listCopies,
+		getCopy:             getCopy,
+		createCopy:          createCopy,
+		updateCopy:          updateCopy,
+		deleteCopy:          deleteCopy,
+		lookupCopyByBarcode: lookupCopyByBarcode,
 	}
 }
 
 // ListBooks returns a list of books in the following cases:
 // 1. When shelfID is valid, returns the books in that shelf.
 // 2. When shelfID is "-", returns all books in the library.
-// 3. When shelfID is invalid, returns empty list.
+// 3. When shelfID doesn't exist, returns empty list.
+//
+// Books are filtered by the request filter, which follows https://google.aip.dev/160,
+// e.g. `author = "Henrod" AND create_time > "2022-01-01T00:00:00Z"`,
+// and sorted by the request order_by, which follows https://google.aip.dev/132#ordering.
 //
 // Method is paginated in the following standard: https://cloud.google.com/apis/design/design_patterns#list_pagination.
 //
-// Book resource name must be in the format: /shelves/:shelfID/books.
+// The parent must be in the format: shelves/{shelf}.
 func (l *LibraryService) ListBooks(ctx context.Context, request *v1.ListBooksRequest) (*v1.ListBooksResponse, error) {
-	parent := strings.Split(request.GetParent(), "/")
-	if len(parent) != 2 {
-		err := status.Errorf(codes.InvalidArgument, "parent must be of format 'shelves/*'")
+	parent, err := resourcename.ParseShelfOrWildcard("parent", request.GetParent())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	shelfName := parent.Shelf
+	pageSize, err := getPageSize(request.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	hash := requestHash(
+		"ListBooks",
+		request.GetParent(),
+		request.GetFilter(),
+		request.GetOrderBy(),
+		strconv.FormatBool(request.GetShowDeleted()),
+		strconv.Itoa(pageSize),
+	)
 
-		return nil, fmt.Errorf("failed to get parent: %w", err)
+	pageCursor, err := l.pageTokens.cursor(request.GetPageToken(), hash)
+	if err != nil {
+		return nil, err
 	}
 
-	shelfName := parent[1]
-	pageSize := getPageSize(request.GetPageSize())
-	pageOffset, err := getPageOffset(l.log, request.GetPageToken())
+	skip, err := getSkip(request.GetSkip())
 	if err != nil {
 		return nil, err
 	}
 
-	eBooks, finishedBooks, err := l.listBooks.List(ctx, shelfName, pageSize, pageOffset)
+	page := pagination.Page{After: pageCursor, Skip: skip, Size: pageSize}
+
+	eBooks, nextCursor, err := l.listBooks.List(
+		ctx, shelfName, request.GetFilter(), request.GetOrderBy(), request.GetShowDeleted(), page,
+	)
 	if err != nil {
 		l.log.With(zap.Error(err)).Error("failed to list books in domain")
 
 		return nil, api.GRPCError(err, nil) //nolint:wrapcheck
 	}
 
-	nextPageToken := ""
-	if !finishedBooks {
-		nextPageToken = getNextIntPageToken(pageOffset + 1)
+	nextPageToken, err := l.pageTokens.nextPageToken(nextCursor, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	var totalSize int
+	if request.GetShowTotalSize() {
+		totalSize, err = l.listBooks.Count(ctx, shelfName, request.GetFilter(), request.GetShowDeleted())
+		if err != nil {
+			l.log.With(zap.Error(err)).Error("failed to count books in domain")
+
+			return nil, api.GRPCError(err, nil) //nolint:wrapcheck
+		}
 	}
 
 	pBooks := make([]*v1.Book, len(eBooks))
@@ -100,21 +264,20 @@ func (l *LibraryService) ListBooks(ctx context.Context, request *v1.ListBooksReq
 	return &v1.ListBooksResponse{
 		Books:         pBooks,
 		NextPageToken: nextPageToken,
+		TotalSize:     int32(totalSize),
 	}, nil
 }
 
 func (l *LibraryService) GetBook(ctx context.Context, request *v1.GetBookRequest) (*v1.Book, error) {
-	resourceName := strings.Split(request.GetName(), "/")
-	if len(resourceName) != 4 {
-		err := status.Errorf(codes.InvalidArgument, "resource name must be of format 'shelves/*/books/*'")
-
-		return nil, fmt.Errorf("failed to get resource name: %w", err)
+	name, err := resourcename.ParseBook("name", request.GetName())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
 	}
 
-	shelfName := resourceName[1]
-	bookName := resourceName[3]
+	shelfName := name.Shelf
+	bookName := name.Book
 
-	book, err := l.getBook.GetBook(ctx, shelfName, bookName)
+	book, err := l.getBook.GetBook(ctx, shelfName, bookName, request.GetShowDeleted())
 	if err != nil {
 		l.log.With(zap.Error(err)).Error("failed to get book in domain")
 
@@ -131,58 +294,94 @@ func (l *LibraryService) GetBook(ctx context.Context, request *v1.GetBookRequest
 	return toProtoBook(book), nil
 }
 
+// CreateBook creates a book in the shelf.
+// Retries with the same request_id return the original response instead of executing it again.
 func (l *LibraryService) CreateBook(ctx context.Context, request *v1.CreateBookRequest) (*v1.Book, error) {
-	parent := strings.Split(request.GetParent(), "/")
-	if len(parent) != 2 {
-		err := status.Errorf(codes.InvalidArgument, "parent must be of format 'shelves/*'")
+	book := new(v1.Book)
+	err := l.idempotent(ctx, "CreateBook", request.GetRequestId(), request, book,
+		func(ctx context.Context) (protov2.Message, error) {
+			return l.executeCreateBook(ctx, request)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
 
-		return nil, fmt.Errorf("failed to get parent: %w", err)
+	return book, nil
+}
+
+func (l *LibraryService) executeCreateBook(ctx context.Context, request *v1.CreateBookRequest) (*v1.Book, error) {
+	parent, err := resourcename.ParseShelf("parent", request.GetParent())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
 	}
 
-	shelfName := parent[1]
-	inputBook := &entities.Book{
-		Name:       request.GetBook().GetName(),
-		Author:     request.GetBook().GetAuthor(),
-		Shelf:      nil,
-		CreateTime: time.Time{},
-		UpdateTime: time.Time{},
+	inputBook, err := toEntityBook("book.", request.GetBook())
+	if err != nil {
+		return nil, err
 	}
 
+	shelfName := parent.Shelf
+	inputBook.Name = request.GetBookId()
+
 	book, err := l.createBook.CreateBook(ctx, shelfName, inputBook)
 	if err != nil {
 		l.log.With(zap.Error(err)).Error("failed to create book in domain")
 
-		return nil, api.GRPCError(err, api.Details{ //nolint:wrapcheck
+		details := api.Details{
 			codes.AlreadyExists: {&errdetails.ResourceInfo{
 				ResourceType: "book",
-				ResourceName: request.GetBook().GetName(),
+				ResourceName: fmt.Sprintf("%s/books/%s", request.GetParent(), request.GetBookId()),
 				Owner:        request.GetParent(),
 				Description:  "the book already exists in shelf",
 			}},
-		})
+		}
+
+		return nil, api.GRPCError(err, details) //nolint:wrapcheck
 	}
 
 	return toProtoBook(book), nil
 }
 
+// UpdateBook updates the book fields of the update mask.
+// Retries with the same request_id return the original response instead of executing it again.
 func (l *LibraryService) UpdateBook(ctx context.Context, request *v1.UpdateBookRequest) (*v1.Book, error) {
-	name := strings.Split(request.GetBook().GetName(), "/")
-	if len(name) != 4 {
-		err := status.Errorf(codes.InvalidArgument, "book name must be of format 'shelves/*/books/*'")
+	book := new(v1.Book)
+	err := l.idempotent(ctx, "UpdateBook", request.GetRequestId(), request, book,
+		func(ctx context.Context) (protov2.Message, error) {
+			return l.executeUpdateBook(ctx, request)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
 
-		return nil, fmt.Errorf("failed to get book name: %w", err)
+	return book, nil
+}
+
+func (l *LibraryService) executeUpdateBook(ctx context.Context, request *v1.UpdateBookRequest) (*v1.Book, error) {
+	name, err := resourcename.ParseBook("book.name", request.GetBook().GetName())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	version, err := fromETag("book.etag", request.GetBook().GetEtag())
+	if err != nil {
+		return nil, err
 	}
 
-	shelfName := name[1]
-	inputBook := &entities.Book{
-		Name:       name[3],
-		Author:     request.GetBook().GetAuthor(),
-		Shelf:      nil,
-		CreateTime: time.Time{},
-		UpdateTime: time.Time{},
+	inputBook, err := toEntityBook("book.", request.GetBook())
+	if err != nil {
+		return nil, err
 	}
 
-	book, err := l.updateBook.UpdateBook(ctx, shelfName, inputBook, request.GetUpdateMask())
+	shelfName := name.Shelf
+	inputBook.Name = name.Book
+	inputBook.Version = version
+
+	book, err := l.updateBook.UpdateBook(
+		ctx, shelfName, inputBook, request.GetUpdateMask(), request.GetAllowMissing(),
+	)
 	if err != nil {
 		l.log.With(zap.Error(err)).Error("failed to update book in domain")
 
@@ -190,13 +389,19 @@ func (l *LibraryService) UpdateBook(ctx context.Context, request *v1.UpdateBookR
 			codes.NotFound: {&errdetails.ResourceInfo{
 				ResourceType: "book",
 				ResourceName: request.GetBook().GetName(),
-				Owner:        fmt.Sprintf("%s/%s", name[0], name[1]),
+				Owner:        name.Parent().String(),
 				Description:  "book not found in shelf",
 			}},
+			codes.AlreadyExists: {&errdetails.ResourceInfo{
+				ResourceType: "book",
+				ResourceName: request.GetBook().GetName(),
+				Owner:        name.Parent().String(),
+				Description:  "the missing book can't be created, it already exists in shelf",
+			}},
 		}
 
-		if badRequestDetail, ok := api.BadRequestDetails(err); ok {
-			details[codes.InvalidArgument] = []proto.Message{badRequestDetail}
+		if abortedDetail, ok := api.AbortedDetails(err); ok {
+			details[codes.Aborted] = []proto.Message{abortedDetail}
 		}
 
 		return nil, api.GRPCError(err, details) //nolint:wrapcheck
@@ -205,172 +410,1951 @@ func (l *LibraryService) UpdateBook(ctx context.Context, request *v1.UpdateBookR
 	return toProtoBook(book), nil
 }
 
+// DeleteBook soft deletes the book.
+// Retries with the same request_id return the original response instead of executing it again.
 func (l *LibraryService) DeleteBook(ctx context.Context, request *v1.DeleteBookRequest) (*emptypb.Empty, error) {
-	name := strings.Split(request.GetName(), "/")
-	if len(name) != 4 {
-		err := status.Errorf(codes.InvalidArgument, "book name must be of format 'shelves/*/books/*'")
+	empty := new(emptypb.Empty)
+	err := l.idempotent(ctx, "DeleteBook", request.GetRequestId(), request, empty,
+		func(ctx context.Context) (protov2.Message, error) {
+			return l.executeDeleteBook(ctx, request)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return empty, nil
+}
+
+func (l *LibraryService) executeDeleteBook(ctx context.Context, request *v1.DeleteBookRequest) (*emptypb.Empty, error) {
+	name, err := resourcename.ParseBook("name", request.GetName())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
 
-		return nil, fmt.Errorf("failed to get book name: %w", err)
+	version, err := fromETag("etag", request.GetEtag())
+	if err != nil {
+		return nil, err
 	}
 
-	shelfName := name[1]
-	bookName := name[3]
+	shelfName := name.Shelf
+	bookName := name.Book
 
-	err := l.deleteBook.DeleteBook(ctx, shelfName, bookName)
+	err = l.deleteBook.DeleteBook(ctx, shelfName, bookName, version)
 	if err != nil {
-		l.log.With(zap.Error(err)).Error("failed to update book in domain")
+		l.log.With(zap.Error(err)).Error("failed to delete book in domain")
 
-		return nil, api.GRPCError(err, api.Details{ //nolint:wrapcheck
+		details := api.Details{
 			codes.NotFound: {&errdetails.ResourceInfo{
 				ResourceType: "book",
 				ResourceName: request.GetName(),
-				Owner:        fmt.Sprintf("%s/%s", name[0], name[1]),
+				Owner:        name.Parent().String(),
 				Description:  "book not found in shelf",
 			}},
-		})
+		}
+
+		if abortedDetail, ok := api.AbortedDetails(err); ok {
+			details[codes.Aborted] = []proto.Message{abortedDetail}
+		}
+
+		return nil, api.GRPCError(err, details) //nolint:wrapcheck
 	}
 
 	return &emptypb.Empty{}, nil
 }
 
-func (l *LibraryService) CreateShelf(
-	ctx context.Context,
-	request *v1.CreateShelfRequest,
-) (*longrunning.Operation, error) {
-	inputShelf := &entities.Shelf{
-		Name:       request.GetShelf().GetName(),
-		CreateTime: time.Time{},
-		UpdateTime: time.Time{},
+// UndeleteBook restores a deleted book, which is possible until its purge_time.
+func (l *LibraryService) UndeleteBook(ctx context.Context, request *v1.UndeleteBookRequest) (*v1.Book, error) {
+	name, err := resourcename.ParseBook("name", request.GetName())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
 	}
 
-	operation, err := l.createShelf.StartCreateShelfOperation(ctx, inputShelf)
+	book, err := l.undeleteBook.UndeleteBook(ctx, name.Shelf, name.Book)
 	if err != nil {
-		l.log.With(zap.Error(err)).Error("failed to start create shelf operation in domain")
+		l.log.With(zap.Error(err)).Error("failed to undelete book in domain")
 
 		return nil, api.GRPCError(err, api.Details{ //nolint:wrapcheck
+			codes.NotFound: {&errdetails.ResourceInfo{
+				ResourceType: "book",
+				ResourceName: request.GetName(),
+				Owner:        name.Parent().String(),
+				Description:  "deleted book not found in shelf, it may have been purged",
+			}},
 			codes.AlreadyExists: {&errdetails.ResourceInfo{
-				ResourceType: "operation",
-				ResourceName: l.createShelf.GetOperationName(inputShelf.Name),
-				Owner:        "library",
-				Description:  "the create shelf operation already exists",
+				ResourceType: "book",
+				ResourceName: request.GetName(),
+				Owner:        name.Parent().String(),
+				Description:  "the book is not deleted",
 			}},
 		})
 	}
 
-	return toLongRunningOperation("CreateShelf", operation), nil
+	return toProtoBook(book), nil
 }
 
-func (l *LibraryService) GetOperation(
-	ctx context.Context,
-	request *v1.GetOperationRequest,
-) (*longrunning.Operation, error) {
-	parts := strings.Split(request.GetName(), "/")
-	// assume only long-running operation is CreateShelf.
-	// request.name = operations/shelves/{shelf_name}
-	if len(parts) != 3 {
-		err := status.Errorf(codes.InvalidArgument, "operation name must be of format 'operations/shelves/*'")
-
-		return nil, fmt.Errorf("failed to get operation: %w", err)
+// MoveBook changes the shelf of a book, returning it with its new resource name.
+func (l *LibraryService) MoveBook(ctx context.Context, request *v1.MoveBookRequest) (*v1.Book, error) {
+	name, err := resourcename.ParseBook("name", request.GetName())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
 	}
 
-	shelfName := parts[len(parts)-1]
-	longRunningOperationName := "CreateShelf"
+	destination, err := resourcename.ParseShelf("destination_shelf", request.GetDestinationShelf())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
 
-	operation, err := l.createShelf.GetOperation(shelfName)
+	book, err := l.moveBook.MoveBook(ctx, name.Shelf, name.Book, destination.Shelf)
 	if err != nil {
-		return nil, api.GRPCError(err, api.Details{ //nolint:wrapcheck
+		l.log.With(zap.Error(err)).Error("failed to move book in domain")
+
+		details := api.Details{
 			codes.NotFound: {&errdetails.ResourceInfo{
-				ResourceType: "operation",
-				ResourceName: l.createShelf.GetOperationName(shelfName),
-				Owner:        "library",
-				Description:  "the operation doesn't exist; is not running nor completed",
+				ResourceType: "book",
+				ResourceName: request.GetName(),
+				Owner:        request.GetDestinationShelf(),
+				Description:  "book or destination shelf not found",
 			}},
-		})
+			codes.AlreadyExists: {&errdetails.ResourceInfo{
+				ResourceType: "book",
+				ResourceName: resourcename.Book{Shelf: destination.Shelf, Book: name.Book}.String(),
+				Owner:        request.GetDestinationShelf(),
+				Description:  "the book already exists in destination shelf",
+			}},
+		}
+
+		return nil, api.GRPCError(err, details) //nolint:wrapcheck
 	}
 
-	longRunningOperation := toLongRunningOperation(longRunningOperationName, operation)
-	if !longRunningOperation.Done || longRunningOperation.Result != nil {
-		return longRunningOperation, nil
+	return toProtoBook(book), nil
+}
+
+// BatchGetBooks returns the books of the request names, which must be from the parent shelf.
+func (l *LibraryService) BatchGetBooks(
+	ctx context.Context,
+	request *v1.BatchGetBooksRequest,
+) (*v1.BatchGetBooksResponse, error) {
+	shelfName, bookNames, err := batchBookNames(request.GetParent(), request.GetNames())
+	if err != nil {
+		return nil, err
 	}
 
-	shelf, err := l.getShelf.GetShelf(ctx, shelfName)
+	eBooks, err := l.batchGetBooks.BatchGetBooks(ctx, shelfName, bookNames)
 	if err != nil {
-		l.log.With(zap.Error(err)).Error("failed to get shelf in domain")
+		l.log.With(zap.Error(err)).Error("failed to batch get books in domain")
 
-		return nil, api.GRPCError(err, api.Details{ //nolint:wrapcheck
+		details := api.Details{
 			codes.NotFound: {&errdetails.ResourceInfo{
-				ResourceType: "operation",
-				ResourceName: request.GetName(),
-				Owner:        "library",
-				Description:  "the operation doesn't exist; is not running nor completed",
+				ResourceType: "book",
+				ResourceName: batchItemName(request.GetNames(), err),
+				Owner:        request.GetParent(),
+				Description:  "the book does not exist in shelf",
 			}},
-		})
+		}
+
+		return nil, api.GRPCError(err, details) //nolint:wrapcheck
 	}
 
-	response, _ := anypb.New(toProtoShelf(shelf))
-	longRunningOperation.Result = &longrunning.Operation_Response{Response: response}
-	longRunningOperation.Done = true
+	pBooks := make([]*v1.Book, len(eBooks))
+	for i, book := range eBooks {
+		pBooks[i] = toProtoBook(book)
+	}
 
-	return longRunningOperation, nil
+	return &v1.BatchGetBooksResponse{Books: pBooks}, nil
 }
 
-func toProtoBook(book *entities.Book) *v1.Book {
-	return &v1.Book{
-		Name:       bookResourceName(book),
-		Author:     book.Author,
-		CreateTime: timestamppb.New(book.CreateTime),
-		UpdateTime: timestamppb.New(book.UpdateTime),
+// BatchCreateBooks creates the books of every request in the parent shelf in a single transaction.
+func (l *LibraryService) BatchCreateBooks(
+	ctx context.Context,
+	request *v1.BatchCreateBooksRequest,
+) (*v1.BatchCreateBooksResponse, error) {
+	parent, err := resourcename.ParseShelf("parent", request.GetParent())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
 	}
-}
 
-func bookResourceName(book *entities.Book) string {
-	return fmt.Sprintf("%s/books/%s", shelfResourceName(book.Shelf), book.Name)
-}
+	shelfName := parent.Shelf
+	inputBooks := make([]*entities.Book, len(request.GetRequests()))
 
-func toProtoShelf(shelf *entities.Shelf) *v1.Shelf {
-	if shelf == nil {
-		return nil
+	for i, createRequest := range request.GetRequests() {
+		if createRequest.GetParent() != "" && createRequest.GetParent() != request.GetParent() {
+			return nil, invalidBatchItem(
+				fmt.Sprintf("requests[%d].parent", i),
+				"parent must be empty or equal to the batch parent",
+			)
+		}
+
+		inputBook, err := toEntityBook(fmt.Sprintf("requests[%d].book.", i), createRequest.GetBook())
+		if err != nil {
+			return nil, err
+		}
+
+		inputBook.Name = createRequest.GetBookId()
+		inputBooks[i] = inputBook
 	}
 
-	return &v1.Shelf{
-		Name:       shelfResourceName(shelf),
-		CreateTime: timestamppb.New(shelf.CreateTime),
-		UpdateTime: timestamppb.New(shelf.UpdateTime),
+	eBooks, err := l.batchCreateBooks.BatchCreateBooks(ctx, shelfName, inputBooks)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to batch create books in domain")
+
+		details := api.Details{
+			codes.NotFound: {&errdetails.ResourceInfo{
+				ResourceType: "shelf",
+				ResourceName: request.GetParent(),
+				Owner:        "",
+				Description:  "the shelf does not exist",
+			}},
+		}
+
+		var batchItemError domainErrors.BatchItemError
+		if errors.As(err, &batchItemError) {
+			details[codes.AlreadyExists] = []proto.Message{&errdetails.ResourceInfo{
+				ResourceType: "book",
+				ResourceName: fmt.Sprintf("%s/books/%s", request.GetParent(), inputBooks[batchItemError.Index].Name),
+				Owner:        request.GetParent(),
+				Description:  "the book already exists in shelf",
+			}}
+		}
+
+		return nil, api.GRPCError(err, details) //nolint:wrapcheck
+	}
+
+	pBooks := make([]*v1.Book, len(eBooks))
+	for i, book := range eBooks {
+		pBooks[i] = toProtoBook(book)
 	}
+
+	return &v1.BatchCreateBooksResponse{Books: pBooks}, nil
 }
 
-func shelfResourceName(shelf *entities.Shelf) string {
-	return fmt.Sprintf("shelves/%s", shelf.Name)
+// BatchDeleteBooks removes the books of the request names, which must be from the parent shelf,
+// in a single transaction.
+func (l *LibraryService) BatchDeleteBooks(
+	ctx context.Context,
+	request *v1.BatchDeleteBooksRequest,
+) (*emptypb.Empty, error) {
+	shelfName, bookNames, err := batchBookNames(request.GetParent(), request.GetNames())
+	if err != nil {
+		return nil, err
+	}
+
+	err = l.batchDeleteBooks.BatchDeleteBooks(ctx, shelfName, bookNames)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to batch delete books in domain")
+
+		details := api.Details{
+			codes.NotFound: {&errdetails.ResourceInfo{
+				ResourceType: "book",
+				ResourceName: batchItemName(request.GetNames(), err),
+				Owner:        request.GetParent(),
+				Description:  "book not found in shelf",
+			}},
+		}
+
+		return nil, api.GRPCError(err, details) //nolint:wrapcheck
+	}
+
+	return &emptypb.Empty{}, nil
 }
 
-func toLongRunningOperation(name string, operation *entities.Operation) *longrunning.Operation {
-	metadata, _ := anypb.New(&v1.Operation{
-		Name:       name,
-		Stage:      operation.Stage,
-		Percentage: uint32(operation.Percentage),
-	})
+// SearchBooks returns the books matching the request query, from the most to the least relevant.
+// The parent "shelves/-" searches books from every shelf.
+//
+// Method is paginated in the following standard: https://cloud.google.com/apis/design/design_patterns#list_pagination.
+func (l *LibraryService) SearchBooks(
+	ctx context.Context,
+	request *v1.SearchBooksRequest,
+) (*v1.SearchBooksResponse, error) {
+	parent, err := resourcename.ParseShelfOrWildcard("parent", request.GetParent())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
 
-	longRunningOperation := &longrunning.Operation{
-		Name:     operation.Name,
-		Metadata: metadata,
-		Done:     operation.Finished(),
-		Result:   nil,
+	pageSize, err := getPageSize(request.GetPageSize())
+	if err != nil {
+		return nil, err
 	}
 
-	if operation.Error != nil {
-		err := api.GRPCError(operation.Error, api.Details{
-			codes.AlreadyExists: {&errdetails.ResourceInfo{
-				ResourceType: "shelf",
-				ResourceName: operation.ResourceName(),
-				Owner:        "library",
-				Description:  "the shelf already exists in the library",
-			}},
-		})
+	hash := requestHash(
+		"SearchBooks", request.GetParent(), request.GetQuery(), request.GetFilter(), strconv.Itoa(pageSize),
+	)
 
-		longRunningOperation.Result = &longrunning.Operation_Error{
-			Error: status.Convert(err).Proto(),
+	pageCursor, err := l.pageTokens.cursor(request.GetPageToken(), hash)
+	if err != nil {
+		return nil, err
+	}
+
+	page := pagination.Page{After: pageCursor, Size: pageSize}
+
+	eResults, nextCursor, err := l.searchBooks.Search(
+		ctx, parent.Shelf, request.GetQuery(), request.GetFilter(), page,
+	)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to search books in domain")
+
+		return nil, api.GRPCError(err, nil) //nolint:wrapcheck
+	}
+
+	var pFacets []*v1.SearchFacet
+
+	if len(request.GetFacets()) > 0 {
+		eFacets, err := l.searchBooks.Facets(
+			ctx, parent.Shelf, request.GetQuery(), request.GetFilter(), request.GetFacets(),
+		)
+		if err != nil {
+			l.log.With(zap.Error(err)).Error("failed to count search facets in domain")
+
+			return nil, api.GRPCError(err, nil) //nolint:wrapcheck
 		}
+
+		pFacets = toProtoSearchFacets(eFacets)
 	}
 
-	return longRunningOperation
+	nextPageToken, err := l.pageTokens.nextPageToken(nextCursor, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	pResults := make([]*v1.SearchBooksResult, len(eResults))
+	for i, result := range eResults {
+		pResults[i] = &v1.SearchBooksResult{
+			Book:      toProtoBook(result.Book),
+			Relevance: result.Relevance,
+			Snippet:   result.Snippet,
+		}
+	}
+
+	return &v1.SearchBooksResponse{
+		Results:       pResults,
+		NextPageToken: nextPageToken,
+		Facets:        pFacets,
+	}, nil
+}
+
+// SuggestBooks returns the titles and authors completing the request prefix, tolerating typos.
+// The parent "shelves/-" suggests from every shelf.
+func (l *LibraryService) SuggestBooks(
+	ctx context.Context,
+	request *v1.SuggestBooksRequest,
+) (*v1.SuggestBooksResponse, error) {
+	parent, err := resourcename.ParseShelfOrWildcard("parent", request.GetParent())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	eSuggestions, err := l.suggestBooks.Suggest(
+		ctx, parent.Shelf, request.GetPrefix(), int(request.GetMaxSuggestions()),
+	)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to suggest books in domain")
+
+		return nil, api.GRPCError(err, nil) //nolint:wrapcheck
+	}
+
+	pSuggestions := make([]*v1.BookSuggestion, len(eSuggestions))
+	for i, suggestion := range eSuggestions {
+		pSuggestions[i] = &v1.BookSuggestion{
+			Text:  suggestion.Text,
+			Field: toProtoSuggestionField(suggestion.Field),
+			Score: suggestion.Score,
+		}
+	}
+
+	return &v1.SuggestBooksResponse{Suggestions: pSuggestions}, nil
+}
+
+// ListShelves returns the shelves in the library.
+//
+// Method is paginated in the following standard: https://cloud.google.com/apis/design/design_patterns#list_pagination.
+func (l *LibraryService) ListShelves(
+	ctx context.Context,
+	request *v1.ListShelvesRequest,
+) (*v1.ListShelvesResponse, error) {
+	pageSize, err := getPageSize(request.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	hash := requestHash("ListShelves", request.GetFilter(), request.GetOrderBy(), strconv.Itoa(pageSize))
+
+	pageCursor, err := l.pageTokens.cursor(request.GetPageToken(), hash)
+	if err != nil {
+		return nil, err
+	}
+
+	page := pagination.Page{After: pageCursor, Size: pageSize}
+
+	eShelves, nextCursor, err := l.listShelves.List(ctx, request.GetFilter(), request.GetOrderBy(), page)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to list shelves in domain")
+
+		return nil, api.GRPCError(err, nil) //nolint:wrapcheck
+	}
+
+	nextPageToken, err := l.pageTokens.nextPageToken(nextCursor, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	pShelves := make([]*v1.Shelf, len(eShelves))
+	for i, shelf := range eShelves {
+		pShelves[i] = toProtoShelf(shelf)
+	}
+
+	return &v1.ListShelvesResponse{
+		Shelves:       pShelves,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func (l *LibraryService) GetShelf(ctx context.Context, request *v1.GetShelfRequest) (*v1.Shelf, error) {
+	name, err := resourcename.ParseShelf("name", request.GetName())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	shelf, err := l.getShelf.GetShelf(ctx, name.Shelf)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to get shelf in domain")
+
+		return nil, api.GRPCError(err, api.Details{ //nolint:wrapcheck
+			codes.NotFound: {&errdetails.ResourceInfo{
+				ResourceType: "shelf",
+				ResourceName: request.GetName(),
+				Owner:        "library",
+				Description:  "the shelf does not exist in the library",
+			}},
+		})
+	}
+
+	return toProtoShelf(shelf), nil
+}
+
+func (l *LibraryService) CreateShelf(
+	ctx context.Context,
+	request *v1.CreateShelfRequest,
+) (*longrunning.Operation, error) {
+	inputShelf := &entities.Shelf{
+		Name:        request.GetShelf().GetName(),
+		DisplayName: request.GetShelf().GetDisplayName(),
+		Labels:      request.GetShelf().GetLabels(),
+		CreateTime:  time.Time{},
+		UpdateTime:  time.Time{},
+	}
+
+	operation, err := l.createShelf.StartCreateShelfOperation(ctx, inputShelf)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to start create shelf operation in domain")
+
+		details := api.Details{
+			codes.AlreadyExists: {&errdetails.ResourceInfo{
+				ResourceType: "operation",
+				ResourceName: l.createShelf.GetOperationName(inputShelf.Name),
+				Owner:        "library",
+				Description:  "the create shelf operation already exists",
+			}},
+		}
+
+		return nil, api.GRPCError(err, details) //nolint:wrapcheck
+	}
+
+	return toLongRunningOperation("CreateShelf", operation, createShelfOperationDetails(operation)), nil
+}
+
+func (l *LibraryService) UpdateShelf(ctx context.Context, request *v1.UpdateShelfRequest) (*v1.Shelf, error) {
+	name, err := resourcename.ParseShelf("shelf.name", request.GetShelf().GetName())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	inputShelf := &entities.Shelf{
+		Name:        name.Shelf,
+		DisplayName: request.GetShelf().GetDisplayName(),
+		Labels:      request.GetShelf().GetLabels(),
+		CreateTime:  time.Time{},
+		UpdateTime:  time.Time{},
+	}
+
+	shelf, err := l.updateShelf.UpdateShelf(ctx, inputShelf, request.GetUpdateMask())
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to update shelf in domain")
+
+		details := api.Details{
+			codes.NotFound: {&errdetails.ResourceInfo{
+				ResourceType: "shelf",
+				ResourceName: request.GetShelf().GetName(),
+				Owner:        "library",
+				Description:  "shelf not found in library",
+			}},
+		}
+
+		return nil, api.GRPCError(err, details) //nolint:wrapcheck
+	}
+
+	return toProtoShelf(shelf), nil
+}
+
+func (l *LibraryService) DeleteShelf(
+	ctx context.Context,
+	request *v1.DeleteShelfRequest,
+) (*longrunning.Operation, error) {
+	name, err := resourcename.ParseShelf("name", request.GetName())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	shelfName := name.Shelf
+
+	operation, err := l.deleteShelf.StartDeleteShelfOperation(ctx, shelfName, request.GetForce())
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to start delete shelf operation in domain")
+
+		return nil, api.GRPCError(err, api.Details{ //nolint:wrapcheck
+			codes.NotFound: {&errdetails.ResourceInfo{
+				ResourceType: "shelf",
+				ResourceName: request.GetName(),
+				Owner:        "library",
+				Description:  "shelf not found in library",
+			}},
+			codes.FailedPrecondition: {&errdetails.PreconditionFailure{
+				Violations: []*errdetails.PreconditionFailure_Violation{{
+					Type:        "NOT_EMPTY",
+					Subject:     request.GetName(),
+					Description: "the shelf has books; set force to delete them along with the shelf",
+				}},
+			}},
+			codes.AlreadyExists: {&errdetails.ResourceInfo{
+				ResourceType: "operation",
+				ResourceName: l.deleteShelf.GetOperationName(shelfName),
+				Owner:        "library",
+				Description:  "the delete shelf operation already exists",
+			}},
+		})
+	}
+
+	return toLongRunningOperation("DeleteShelf", operation, deleteShelfOperationDetails(shelfName)), nil
+}
+
+// ListAuthors returns the authors in the library.
+//
+// Method is paginated in the following standard: https://cloud.google.com/apis/design/design_patterns#list_pagination.
+func (l *LibraryService) ListAuthors(
+	ctx context.Context,
+	request *v1.ListAuthorsRequest,
+) (*v1.ListAuthorsResponse, error) {
+	pageSize, err := getPageSize(request.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	hash := requestHash("ListAuthors", request.GetFilter(), request.GetOrderBy(), strconv.Itoa(pageSize))
+
+	pageCursor, err := l.pageTokens.cursor(request.GetPageToken(), hash)
+	if err != nil {
+		return nil, err
+	}
+
+	page := pagination.Page{After: pageCursor, Size: pageSize}
+
+	eAuthors, nextCursor, err := l.listAuthors.List(ctx, request.GetFilter(), request.GetOrderBy(), page)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to list authors in domain")
+
+		return nil, api.GRPCError(err, nil) //nolint:wrapcheck
+	}
+
+	nextPageToken, err := l.pageTokens.nextPageToken(nextCursor, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	pAuthors := make([]*v1.Author, len(eAuthors))
+	for i, author := range eAuthors {
+		pAuthors[i] = toProtoAuthor(author)
+	}
+
+	return &v1.ListAuthorsResponse{
+		Authors:       pAuthors,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func (l *LibraryService) GetAuthor(ctx context.Context, request *v1.GetAuthorRequest) (*v1.Author, error) {
+	name, err := resourcename.ParseAuthor("name", request.GetName())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	author, err := l.getAuthor.GetAuthor(ctx, name.Author)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to get author in domain")
+
+		return nil, api.GRPCError(err, api.Details{ //nolint:wrapcheck
+			codes.NotFound: {&errdetails.ResourceInfo{
+				ResourceType: "author",
+				ResourceName: request.GetName(),
+				Owner:        "library",
+				Description:  "the author does not exist in the library",
+			}},
+		})
+	}
+
+	return toProtoAuthor(author), nil
+}
+
+func (l *LibraryService) CreateAuthor(ctx context.Context, request *v1.CreateAuthorRequest) (*v1.Author, error) {
+	inputAuthor := &entities.Author{
+		Name:        request.GetAuthorId(),
+		DisplayName: request.GetAuthor().GetDisplayName(),
+		CreateTime:  time.Time{},
+		UpdateTime:  time.Time{},
+	}
+
+	author, err := l.createAuthor.CreateAuthor(ctx, inputAuthor)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to create author in domain")
+
+		details := api.Details{
+			codes.AlreadyExists: {&errdetails.ResourceInfo{
+				ResourceType: "author",
+				ResourceName: authorResourceName(inputAuthor.Name),
+				Owner:        "library",
+				Description:  "the author already exists in the library",
+			}},
+		}
+
+		return nil, api.GRPCError(err, details) //nolint:wrapcheck
+	}
+
+	return toProtoAuthor(author), nil
+}
+
+func (l *LibraryService) UpdateAuthor(ctx context.Context, request *v1.UpdateAuthorRequest) (*v1.Author, error) {
+	name, err := resourcename.ParseAuthor("author.name", request.GetAuthor().GetName())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	inputAuthor := &entities.Author{
+		Name:        name.Author,
+		DisplayName: request.GetAuthor().GetDisplayName(),
+		CreateTime:  time.Time{},
+		UpdateTime:  time.Time{},
+	}
+
+	author, err := l.updateAuthor.UpdateAuthor(ctx, inputAuthor, request.GetUpdateMask())
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to update author in domain")
+
+		details := api.Details{
+			codes.NotFound: {&errdetails.ResourceInfo{
+				ResourceType: "author",
+				ResourceName: request.GetAuthor().GetName(),
+				Owner:        "library",
+				Description:  "author not found in library",
+			}},
+		}
+
+		return nil, api.GRPCError(err, details) //nolint:wrapcheck
+	}
+
+	return toProtoAuthor(author), nil
+}
+
+func (l *LibraryService) DeleteAuthor(ctx context.Context, request *v1.DeleteAuthorRequest) (*emptypb.Empty, error) {
+	name, err := resourcename.ParseAuthor("name", request.GetName())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	err = l.deleteAuthor.DeleteAuthor(ctx, name.Author)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to delete author in domain")
+
+		return nil, api.GRPCError(err, api.Details{ //nolint:wrapcheck
+			codes.NotFound: {&errdetails.ResourceInfo{
+				ResourceType: "author",
+				ResourceName: request.GetName(),
+				Owner:        "library",
+				Description:  "author not found in library",
+			}},
+			codes.FailedPrecondition: {&errdetails.PreconditionFailure{
+				Violations: []*errdetails.PreconditionFailure_Violation{{
+					Type:        "HAS_BOOKS",
+					Subject:     request.GetName(),
+					Description: "the author has books; link them to another author or unlink them first",
+				}},
+			}},
+		})
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// ListAuthorBooks returns the books of an author across every shelf, sorted by shelf and name.
+//
+// Method is paginated in the following standard: https://cloud.google.com/apis/design/design_patterns#list_pagination.
+func (l *LibraryService) ListAuthorBooks(
+	ctx context.Context,
+	request *v1.ListAuthorBooksRequest,
+) (*v1.ListAuthorBooksResponse, error) {
+	parent, err := resourcename.ParseAuthor("parent", request.GetParent())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	pageSize, err := getPageSize(request.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	// ListAuthorBooks has no filter nor order_by, so the parent and page size are all its list params.
+	hash := requestHash("ListAuthorBooks", request.GetParent(), strconv.Itoa(pageSize))
+
+	pageCursor, err := l.pageTokens.cursor(request.GetPageToken(), hash)
+	if err != nil {
+		return nil, err
+	}
+
+	page := pagination.Page{After: pageCursor, Size: pageSize}
+
+	eBooks, nextCursor, err := l.listAuthorBooks.List(ctx, parent.Author, page)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to list author books in domain")
+
+		details := api.Details{
+			codes.NotFound: {&errdetails.ResourceInfo{
+				ResourceType: "author",
+				ResourceName: request.GetParent(),
+				Owner:        "library",
+				Description:  "the author does not exist in the library",
+			}},
+		}
+
+		return nil, api.GRPCError(err, details) //nolint:wrapcheck
+	}
+
+	nextPageToken, err := l.pageTokens.nextPageToken(nextCursor, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	pBooks := make([]*v1.Book, len(eBooks))
+	for i, book := range eBooks {
+		pBooks[i] = toProtoBook(book)
+	}
+
+	return &v1.ListAuthorBooksResponse{
+		Books:         pBooks,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// ListSeries returns the series in the library.
+//
+// Method is paginated in the following standard: https://cloud.google.com/apis/design/design_patterns#list_pagination.
+func (l *LibraryService) ListSeries(
+	ctx context.Context,
+	request *v1.ListSeriesRequest,
+) (*v1.ListSeriesResponse, error) {
+	pageSize, err := getPageSize(request.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	hash := requestHash("ListSeries", request.GetFilter(), request.GetOrderBy(), strconv.Itoa(pageSize))
+
+	pageCursor, err := l.pageTokens.cursor(request.GetPageToken(), hash)
+	if err != nil {
+		return nil, err
+	}
+
+	page := pagination.Page{After: pageCursor, Size: pageSize}
+
+	eSeries, nextCursor, err := l.listSeries.List(ctx, request.GetFilter(), request.GetOrderBy(), page)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to list series in domain")
+
+		return nil, api.GRPCError(err, nil) //nolint:wrapcheck
+	}
+
+	nextPageToken, err := l.pageTokens.nextPageToken(nextCursor, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	pSeries := make([]*v1.Series, len(eSeries))
+	for i, series := range eSeries {
+		pSeries[i] = toProtoSeries(series)
+	}
+
+	return &v1.ListSeriesResponse{
+		Series:        pSeries,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func (l *LibraryService) GetSeries(ctx context.Context, request *v1.GetSeriesRequest) (*v1.Series, error) {
+	name, err := resourcename.ParseSeries("name", request.GetName())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	series, err := l.getSeries.GetSeries(ctx, name.Series)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to get series in domain")
+
+		return nil, api.GRPCError(err, api.Details{ //nolint:wrapcheck
+			codes.NotFound: {&errdetails.ResourceInfo{
+				ResourceType: "series",
+				ResourceName: request.GetName(),
+				Owner:        "library",
+				Description:  "the series does not exist in the library",
+			}},
+		})
+	}
+
+	return toProtoSeries(series), nil
+}
+
+func (l *LibraryService) CreateSeries(ctx context.Context, request *v1.CreateSeriesRequest) (*v1.Series, error) {
+	inputSeries := &entities.Series{
+		Name:        request.GetSeriesId(),
+		DisplayName: request.GetSeries().GetDisplayName(),
+		VolumeCount: int(request.GetSeries().GetVolumeCount()),
+		CreateTime:  time.Time{},
+		UpdateTime:  time.Time{},
+	}
+
+	series, err := l.createSeries.CreateSeries(ctx, inputSeries)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to create series in domain")
+
+		details := api.Details{
+			codes.AlreadyExists: {&errdetails.ResourceInfo{
+				ResourceType: "series",
+				ResourceName: seriesResourceName(inputSeries.Name),
+				Owner:        "library",
+				Description:  "the series already exists in the library",
+			}},
+		}
+
+		return nil, api.GRPCError(err, details) //nolint:wrapcheck
+	}
+
+	return toProtoSeries(series), nil
+}
+
+func (l *LibraryService) UpdateSeries(ctx context.Context, request *v1.UpdateSeriesRequest) (*v1.Series, error) {
+	name, err := resourcename.ParseSeries("series.name", request.GetSeries().GetName())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	inputSeries := &entities.Series{
+		Name:        name.Series,
+		DisplayName: request.GetSeries().GetDisplayName(),
+		VolumeCount: int(request.GetSeries().GetVolumeCount()),
+		CreateTime:  time.Time{},
+		UpdateTime:  time.Time{},
+	}
+
+	series, err := l.updateSeries.UpdateSeries(ctx, inputSeries, request.GetUpdateMask())
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to update series in domain")
+
+		details := api.Details{
+			codes.NotFound: {&errdetails.ResourceInfo{
+				ResourceType: "series",
+				ResourceName: request.GetSeries().GetName(),
+				Owner:        "library",
+				Description:  "series not found in library",
+			}},
+		}
+
+		return nil, api.GRPCError(err, details) //nolint:wrapcheck
+	}
+
+	return toProtoSeries(series), nil
+}
+
+func (l *LibraryService) DeleteSeries(ctx context.Context, request *v1.DeleteSeriesRequest) (*emptypb.Empty, error) {
+	name, err := resourcename.ParseSeries("name", request.GetName())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	err = l.deleteSeries.DeleteSeries(ctx, name.Series)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to delete series in domain")
+
+		return nil, api.GRPCError(err, api.Details{ //nolint:wrapcheck
+			codes.NotFound: {&errdetails.ResourceInfo{
+				ResourceType: "series",
+				ResourceName: request.GetName(),
+				Owner:        "library",
+				Description:  "series not found in library",
+			}},
+			codes.FailedPrecondition: {&errdetails.PreconditionFailure{
+				Violations: []*errdetails.PreconditionFailure_Violation{{
+					Type:        "HAS_BOOKS",
+					Subject:     request.GetName(),
+					Description: "the series has books; remove them from the series first",
+				}},
+			}},
+		})
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// ListSeriesBooks returns the books of a series across every shelf, sorted by volume number,
+// and the volumes of the series missing in the library.
+//
+// Method is paginated in the following standard: https://cloud.google.com/apis/design/design_patterns#list_pagination.
+func (l *LibraryService) ListSeriesBooks(
+	ctx context.Context,
+	request *v1.ListSeriesBooksRequest,
+) (*v1.ListSeriesBooksResponse, error) {
+	parent, err := resourcename.ParseSeries("parent", request.GetParent())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	pageSize, err := getPageSize(request.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	// ListSeriesBooks has no filter nor order_by, so the parent and page size are all its list params.
+	hash := requestHash("ListSeriesBooks", request.GetParent(), strconv.Itoa(pageSize))
+
+	pageCursor, err := l.pageTokens.cursor(request.GetPageToken(), hash)
+	if err != nil {
+		return nil, err
+	}
+
+	page := pagination.Page{After: pageCursor, Size: pageSize}
+
+	eBooks, missingVolumes, nextCursor, err := l.listSeriesBooks.List(ctx, parent.Series, page)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to list series books in domain")
+
+		details := api.Details{
+			codes.NotFound: {&errdetails.ResourceInfo{
+				ResourceType: "series",
+				ResourceName: request.GetParent(),
+				Owner:        "library",
+				Description:  "the series does not exist in the library",
+			}},
+		}
+
+		return nil, api.GRPCError(err, details) //nolint:wrapcheck
+	}
+
+	nextPageToken, err := l.pageTokens.nextPageToken(nextCursor, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	pBooks := make([]*v1.Book, len(eBooks))
+	for i, book := range eBooks {
+		pBooks[i] = toProtoBook(book)
+	}
+
+	pMissingVolumes := make([]int32, len(missingVolumes))
+	for i, volume := range missingVolumes {
+		pMissingVolumes[i] = int32(volume)
+	}
+
+	return &v1.ListSeriesBooksResponse{
+		Books:          pBooks,
+		NextPageToken:  nextPageToken,
+		MissingVolumes: pMissingVolumes,
+	}, nil
+}
+
+// ListWorks returns the works in the library.
+//
+// Method is paginated in the following standard: https://cloud.google.com/apis/design/design_patterns#list_pagination.
+func (l *LibraryService) ListWorks(
+	ctx context.Context,
+	request *v1.ListWorksRequest,
+) (*v1.ListWorksResponse, error) {
+	pageSize, err := getPageSize(request.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	hash := requestHash("ListWorks", request.GetFilter(), request.GetOrderBy(), strconv.Itoa(pageSize))
+
+	pageCursor, err := l.pageTokens.cursor(request.GetPageToken(), hash)
+	if err != nil {
+		return nil, err
+	}
+
+	page := pagination.Page{After: pageCursor, Size: pageSize}
+
+	eWorks, nextCursor, err := l.listWorks.List(ctx, request.GetFilter(), request.GetOrderBy(), page)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to list works in domain")
+
+		return nil, api.GRPCError(err, nil) //nolint:wrapcheck
+	}
+
+	nextPageToken, err := l.pageTokens.nextPageToken(nextCursor, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	pWorks := make([]*v1.Work, len(eWorks))
+	for i, work := range eWorks {
+		pWorks[i] = toProtoWork(work)
+	}
+
+	return &v1.ListWorksResponse{
+		Works:         pWorks,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func (l *LibraryService) GetWork(ctx context.Context, request *v1.GetWorkRequest) (*v1.Work, error) {
+	name, err := resourcename.ParseWork("name", request.GetName())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	work, err := l.getWork.GetWork(ctx, name.Work)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to get work in domain")
+
+		return nil, api.GRPCError(err, api.Details{ //nolint:wrapcheck
+			codes.NotFound: {&errdetails.ResourceInfo{
+				ResourceType: "work",
+				ResourceName: request.GetName(),
+				Owner:        "library",
+				Description:  "the work does not exist in the library",
+			}},
+		})
+	}
+
+	return toProtoWork(work), nil
+}
+
+func (l *LibraryService) CreateWork(ctx context.Context, request *v1.CreateWorkRequest) (*v1.Work, error) {
+	inputWork := &entities.Work{
+		Name:        request.GetWorkId(),
+		DisplayName: request.GetWork().GetDisplayName(),
+		CreateTime:  time.Time{},
+		UpdateTime:  time.Time{},
+	}
+
+	work, err := l.createWork.CreateWork(ctx, inputWork)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to create work in domain")
+
+		details := api.Details{
+			codes.AlreadyExists: {&errdetails.ResourceInfo{
+				ResourceType: "work",
+				ResourceName: workResourceName(inputWork.Name),
+				Owner:        "library",
+				Description:  "the work already exists in the library",
+			}},
+		}
+
+		return nil, api.GRPCError(err, details) //nolint:wrapcheck
+	}
+
+	return toProtoWork(work), nil
+}
+
+func (l *LibraryService) UpdateWork(ctx context.Context, request *v1.UpdateWorkRequest) (*v1.Work, error) {
+	name, err := resourcename.ParseWork("work.name", request.GetWork().GetName())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	inputWork := &entities.Work{
+		Name:        name.Work,
+		DisplayName: request.GetWork().GetDisplayName(),
+		CreateTime:  time.Time{},
+		UpdateTime:  time.Time{},
+	}
+
+	work, err := l.updateWork.UpdateWork(ctx, inputWork, request.GetUpdateMask())
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to update work in domain")
+
+		details := api.Details{
+			codes.NotFound: {&errdetails.ResourceInfo{
+				ResourceType: "work",
+				ResourceName: request.GetWork().GetName(),
+				Owner:        "library",
+				Description:  "work not found in library",
+			}},
+		}
+
+		return nil, api.GRPCError(err, details) //nolint:wrapcheck
+	}
+
+	return toProtoWork(work), nil
+}
+
+func (l *LibraryService) DeleteWork(ctx context.Context, request *v1.DeleteWorkRequest) (*emptypb.Empty, error) {
+	name, err := resourcename.ParseWork("name", request.GetName())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	err = l.deleteWork.DeleteWork(ctx, name.Work)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to delete work in domain")
+
+		return nil, api.GRPCError(err, api.Details{ //nolint:wrapcheck
+			codes.NotFound: {&errdetails.ResourceInfo{
+				ResourceType: "work",
+				ResourceName: request.GetName(),
+				Owner:        "library",
+				Description:  "work not found in library",
+			}},
+			codes.FailedPrecondition: {&errdetails.PreconditionFailure{
+				Violations: []*errdetails.PreconditionFailure_Violation{{
+					Type:        "HAS_BOOKS",
+					Subject:     request.GetName(),
+					Description: "the work has books; link them to another work or unlink them first",
+				}},
+			}},
+		})
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// ListWorkEditions returns the editions of a work across every shelf, sorted by shelf and name.
+//
+// Method is paginated in the following standard: https://cloud.google.com/apis/design/design_patterns#list_pagination.
+func (l *LibraryService) ListWorkEditions(
+	ctx context.Context,
+	request *v1.ListWorkEditionsRequest,
+) (*v1.ListWorkEditionsResponse, error) {
+	parent, err := resourcename.ParseWork("parent", request.GetParent())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	pageSize, err := getPageSize(request.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	// ListWorkEditions has no filter nor order_by, so the parent and page size are all its list params.
+	hash := requestHash("ListWorkEditions", request.GetParent(), strconv.Itoa(pageSize))
+
+	pageCursor, err := l.pageTokens.cursor(request.GetPageToken(), hash)
+	if err != nil {
+		return nil, err
+	}
+
+	page := pagination.Page{After: pageCursor, Size: pageSize}
+
+	eBooks, nextCursor, err := l.listWorkEditions.List(ctx, parent.Work, page)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to list work editions in domain")
+
+		details := api.Details{
+			codes.NotFound: {&errdetails.ResourceInfo{
+				ResourceType: "work",
+				ResourceName: request.GetParent(),
+				Owner:        "library",
+				Description:  "the work does not exist in the library",
+			}},
+		}
+
+		return nil, api.GRPCError(err, details) //nolint:wrapcheck
+	}
+
+	nextPageToken, err := l.pageTokens.nextPageToken(nextCursor, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	pBooks := make([]*v1.Book, len(eBooks))
+	for i, book := range eBooks {
+		pBooks[i] = toProtoBook(book)
+	}
+
+	return &v1.ListWorkEditionsResponse{
+		Books:         pBooks,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// ListCopies returns the physical copies of a book.
+//
+// Method is paginated in the following standard: https://cloud.google.com/apis/design/design_patterns#list_pagination.
+//
+// The parent must be in the format: shelves/{shelf}/books/{book}.
+func (l *LibraryService) ListCopies(
+	ctx context.Context,
+	request *v1.ListCopiesRequest,
+) (*v1.ListCopiesResponse, error) {
+	parent, err := resourcename.ParseBook("parent", request.GetParent())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	pageSize, err := getPageSize(request.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	hash := requestHash(
+		"ListCopies", request.GetParent(), request.GetFilter(), request.GetOrderBy(), strconv.Itoa(pageSize),
+	)
+
+	pageCursor, err := l.pageTokens.cursor(request.GetPageToken(), hash)
+	if err != nil {
+		return nil, err
+	}
+
+	page := pagination.Page{After: pageCursor, Size: pageSize}
+
+	eCopies, nextCursor, err := l.listCopies.List(
+		ctx, parent.Shelf, parent.Book, request.GetFilter(), request.GetOrderBy(), page,
+	)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to list copies in domain")
+
+		details := api.Details{
+			codes.NotFound: {&errdetails.ResourceInfo{
+				ResourceType: "book",
+				ResourceName: request.GetParent(),
+				Owner:        parent.Parent().String(),
+				Description:  "the book does not exist in shelf",
+			}},
+		}
+
+		return nil, api.GRPCError(err, details) //nolint:wrapcheck
+	}
+
+	nextPageToken, err := l.pageTokens.nextPageToken(nextCursor, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	pCopies := make([]*v1.Copy, len(eCopies))
+	for i, bookCopy := range eCopies {
+		pCopies[i] = toProtoCopy(bookCopy)
+	}
+
+	return &v1.ListCopiesResponse{
+		Copies:        pCopies,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func (l *LibraryService) GetCopy(ctx context.Context, request *v1.GetCopyRequest) (*v1.Copy, error) {
+	name, err := resourcename.ParseCopy("name", request.GetName())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	bookCopy, err := l.getCopy.GetCopy(ctx, name.Shelf, name.Book, name.Copy)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to get copy in domain")
+
+		return nil, api.GRPCError(err, api.Details{ //nolint:wrapcheck
+			codes.NotFound: {&errdetails.ResourceInfo{
+				ResourceType: "copy",
+				ResourceName: request.GetName(),
+				Owner:        name.Parent().String(),
+				Description:  "the copy does not exist in book",
+			}},
+		})
+	}
+
+	return toProtoCopy(bookCopy), nil
+}
+
+func (l *LibraryService) CreateCopy(ctx context.Context, request *v1.CreateCopyRequest) (*v1.Copy, error) {
+	parent, err := resourcename.ParseBook("parent", request.GetParent())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	acquisitionDate, err := copyAcquisitionDate("copy.acquisition_date", request.GetCopy().GetAcquisitionDate())
+	if err != nil {
+		return nil, err
+	}
+
+	inputCopy := &entities.Copy{
+		Name:            request.GetCopyId(),
+		ShelfName:       parent.Shelf,
+		BookName:        parent.Book,
+		Barcode:         request.GetCopy().GetBarcode(),
+		AcquisitionDate: acquisitionDate,
+		Condition:       toEntityCopyCondition(request.GetCopy().GetCondition()),
+		Status:          toEntityCopyStatus(request.GetCopy().GetStatus()),
+		CreateTime:      time.Time{},
+		UpdateTime:      time.Time{},
+	}
+
+	bookCopy, err := l.createCopy.CreateCopy(ctx, inputCopy)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to create copy in domain")
+
+		details := api.Details{
+			codes.NotFound: {&errdetails.ResourceInfo{
+				ResourceType: "book",
+				ResourceName: request.GetParent(),
+				Owner:        parent.Parent().String(),
+				Description:  "the book does not exist in shelf",
+			}},
+			codes.AlreadyExists: {&errdetails.ResourceInfo{
+				ResourceType: "copy",
+				ResourceName: fmt.Sprintf("%s/copies/%s", request.GetParent(), inputCopy.Name),
+				Owner:        request.GetParent(),
+				Description:  "the copy already exists in book, or another copy has its barcode",
+			}},
+		}
+
+		return nil, api.GRPCError(err, details) //nolint:wrapcheck
+	}
+
+	return toProtoCopy(bookCopy), nil
+}
+
+func (l *LibraryService) UpdateCopy(ctx context.Context, request *v1.UpdateCopyRequest) (*v1.Copy, error) {
+	name, err := resourcename.ParseCopy("copy.name", request.GetCopy().GetName())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	acquisitionDate, err := copyAcquisitionDate("copy.acquisition_date", request.GetCopy().GetAcquisitionDate())
+	if err != nil {
+		return nil, err
+	}
+
+	inputCopy := &entities.Copy{
+		Name:            name.Copy,
+		ShelfName:       name.Shelf,
+		BookName:        name.Book,
+		Barcode:         request.GetCopy().GetBarcode(),
+		AcquisitionDate: acquisitionDate,
+		Condition:       toEntityCopyCondition(request.GetCopy().GetCondition()),
+		Status:          toEntityCopyStatus(request.GetCopy().GetStatus()),
+		CreateTime:      time.Time{},
+		UpdateTime:      time.Time{},
+	}
+
+	bookCopy, err := l.updateCopy.UpdateCopy(ctx, inputCopy, request.GetUpdateMask())
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to update copy in domain")
+
+		details := api.Details{
+			codes.NotFound: {&errdetails.ResourceInfo{
+				ResourceType: "copy",
+				ResourceName: request.GetCopy().GetName(),
+				Owner:        name.Parent().String(),
+				Description:  "copy not found in book",
+			}},
+			codes.AlreadyExists: {&errdetails.ResourceInfo{
+				ResourceType: "copy",
+				ResourceName: request.GetCopy().GetName(),
+				Owner:        name.Parent().String(),
+				Description:  "another copy has the barcode",
+			}},
+		}
+
+		return nil, api.GRPCError(err, details) //nolint:wrapcheck
+	}
+
+	return toProtoCopy(bookCopy), nil
+}
+
+func (l *LibraryService) DeleteCopy(ctx context.Context, request *v1.DeleteCopyRequest) (*emptypb.Empty, error) {
+	name, err := resourcename.ParseCopy("name", request.GetName())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	err = l.deleteCopy.DeleteCopy(ctx, name.Shelf, name.Book, name.Copy)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to delete copy in domain")
+
+		return nil, api.GRPCError(err, api.Details{ //nolint:wrapcheck
+			codes.NotFound: {&errdetails.ResourceInfo{
+				ResourceType: "copy",
+				ResourceName: request.GetName(),
+				Owner:        name.Parent().String(),
+				Description:  "copy not found in book",
+			}},
+		})
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// LookupCopyByBarcode returns the copy of any book with the barcode, as read by a scanner.
+func (l *LibraryService) LookupCopyByBarcode(
+	ctx context.Context,
+	request *v1.LookupCopyByBarcodeRequest,
+) (*v1.Copy, error) {
+	bookCopy, err := l.lookupCopyByBarcode.LookupCopyByBarcode(ctx, request.GetBarcode())
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to lookup copy by barcode in domain")
+
+		details := api.Details{
+			codes.NotFound: {&errdetails.ResourceInfo{
+				ResourceType: "copy",
+				ResourceName: "",
+				Owner:        "library",
+				Description:  fmt.Sprintf("no copy has barcode %s", request.GetBarcode()),
+			}},
+		}
+
+		return nil, api.GRPCError(err, details) //nolint:wrapcheck
+	}
+
+	return toProtoCopy(bookCopy), nil
+}
+
+// GetOperation returns the state of a shelf long-running operation.
+// Supported operation names are:
+// - operations/shelves/{shelf_name}: CreateShelf.
+// - operations/shelves/{shelf_name}/delete: DeleteShelf.
+func (l *LibraryService) GetOperation(
+	ctx context.Context,
+	request *v1.GetOperationRequest,
+) (*longrunning.Operation, error) {
+	name, err := resourcename.ParseOperation("name", request.GetName())
+	if err != nil {
+		return nil, api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	if name.Delete {
+		return l.getDeleteShelfOperation(request, name.Shelf)
+	}
+
+	return l.getCreateShelfOperation(ctx, request, name.Shelf)
+}
+
+func (l *LibraryService) getCreateShelfOperation(
+	ctx context.Context,
+	request *v1.GetOperationRequest,
+	shelfName string,
+) (*longrunning.Operation, error) {
+	longRunningOperationName := "CreateShelf"
+
+	operation, err := l.createShelf.GetOperation(shelfName)
+	if err != nil {
+		return nil, api.GRPCError(err, api.Details{ //nolint:wrapcheck
+			codes.NotFound: {&errdetails.ResourceInfo{
+				ResourceType: "operation",
+				ResourceName: l.createShelf.GetOperationName(shelfName),
+				Owner:        "library",
+				Description:  "the operation doesn't exist; is not running nor completed",
+			}},
+		})
+	}
+
+	longRunningOperation := toLongRunningOperation(
+		longRunningOperationName, operation, createShelfOperationDetails(operation),
+	)
+	if !longRunningOperation.Done || longRunningOperation.Result != nil {
+		return longRunningOperation, nil
+	}
+
+	shelf, err := l.getShelf.GetShelf(ctx, shelfName)
+	if err != nil {
+		l.log.With(zap.Error(err)).Error("failed to get shelf in domain")
+
+		return nil, api.GRPCError(err, api.Details{ //nolint:wrapcheck
+			codes.NotFound: {&errdetails.ResourceInfo{
+				ResourceType: "operation",
+				ResourceName: request.GetName(),
+				Owner:        "library",
+				Description:  "the operation doesn't exist; is not running nor completed",
+			}},
+		})
+	}
+
+	response, _ := anypb.New(toProtoShelf(shelf))
+	longRunningOperation.Result = &longrunning.Operation_Response{Response: response}
+	longRunningOperation.Done = true
+
+	return longRunningOperation, nil
+}
+
+func (l *LibraryService) getDeleteShelfOperation(
+	request *v1.GetOperationRequest,
+	shelfName string,
+) (*longrunning.Operation, error) {
+	operation, err := l.deleteShelf.GetOperation(shelfName)
+	if err != nil {
+		return nil, api.GRPCError(err, api.Details{ //nolint:wrapcheck
+			codes.NotFound: {&errdetails.ResourceInfo{
+				ResourceType: "operation",
+				ResourceName: request.GetName(),
+				Owner:        "library",
+				Description:  "the operation doesn't exist; is not running nor completed",
+			}},
+		})
+	}
+
+	longRunningOperation := toLongRunningOperation("DeleteShelf", operation, deleteShelfOperationDetails(shelfName))
+	if longRunningOperation.Done && longRunningOperation.Result == nil {
+		response, _ := anypb.New(&emptypb.Empty{})
+		longRunningOperation.Result = &longrunning.Operation_Response{Response: response}
+	}
+
+	return longRunningOperation, nil
+}
+
+// bookAuthorName returns the ID of the book author resource name, which is empty if the book has no author.
+func bookAuthorName(field, name string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+
+	author, err := resourcename.ParseAuthor(field, name)
+	if err != nil {
+		return "", api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	return author.Author, nil
+}
+
+// bookSeriesName returns the ID of the book series resource name, which is empty if the book has no series.
+func bookSeriesName(field, name string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+
+	series, err := resourcename.ParseSeries(field, name)
+	if err != nil {
+		return "", api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	return series.Series, nil
+}
+
+// bookWorkName returns the ID of the book work resource name, which is empty if the book has no work.
+func bookWorkName(field, name string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+
+	work, err := resourcename.ParseWork(field, name)
+	if err != nil {
+		return "", api.InvalidArgument(err) //nolint:wrapcheck
+	}
+
+	return work.Work, nil
+}
+
+func toProtoBook(book *entities.Book) *v1.Book {
+	return &v1.Book{
+		Name:            bookResourceName(book),
+		Author:          book.Author(),
+		AuthorName:      authorResourceName(book.AuthorName),
+		Contributors:    toProtoContributors(book.Contributors),
+		Series:          seriesResourceName(book.SeriesName),
+		VolumeNumber:    int32(book.VolumeNumber),
+		Work:            workResourceName(book.WorkName),
+		Title:           book.Title,
+		Isbn:            book.ISBN,
+		Publisher:       book.Publisher,
+		PublicationYear: int32(book.PublicationYear),
+		Language:        book.Language,
+		PageCount:       int32(book.PageCount),
+		Labels:          book.Labels,
+		Description:     book.Description,
+		CreateTime:      timestamppb.New(book.CreateTime),
+		UpdateTime:      timestamppb.New(book.UpdateTime),
+		DeleteTime:      toProtoOptionalTime(book.DeleteTime),
+		PurgeTime:       toProtoOptionalTime(book.PurgeTime),
+		Etag:            toETag(book.Version),
+	}
+}
+
+// toEntityBook returns the user settable fields of book, without its name and version.
+// Invalid resource names are reported as prefix + field, e.g. "book.author_name".
+func toEntityBook(prefix string, book *v1.Book) (*entities.Book, error) {
+	authorName, err := bookAuthorName(prefix+"author_name", book.GetAuthorName())
+	if err != nil {
+		return nil, err
+	}
+
+	seriesName, err := bookSeriesName(prefix+"series", book.GetSeries())
+	if err != nil {
+		return nil, err
+	}
+
+	workName, err := bookWorkName(prefix+"work", book.GetWork())
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.Book{
+		Name:            "",
+		Contributors:    toEntityContributors(book),
+		AuthorName:      authorName,
+		SeriesName:      seriesName,
+		VolumeNumber:    int(book.GetVolumeNumber()),
+		WorkName:        workName,
+		Title:           book.GetTitle(),
+		ISBN:            book.GetIsbn(),
+		Publisher:       book.GetPublisher(),
+		PublicationYear: int(book.GetPublicationYear()),
+		Language:        book.GetLanguage(),
+		PageCount:       int(book.GetPageCount()),
+		Labels:          book.GetLabels(),
+		Description:     book.GetDescription(),
+		Shelf:           nil,
+		CreateTime:      time.Time{},
+		UpdateTime:      time.Time{},
+		DeleteTime:      time.Time{},
+		PurgeTime:       time.Time{},
+		Version:         0,
+	}, nil
+}
+
+// toEntityContributors returns the book contributors.
+// For compatibility, a book with only the author field has it as its single author contributor.
+func toEntityContributors(book *v1.Book) []*entities.Contributor {
+	if len(book.GetContributors()) == 0 && book.GetAuthor() != "" {
+		return []*entities.Contributor{{Name: book.GetAuthor(), Role: entities.ContributorRoleAuthor}}
+	}
+
+	contributors := make([]*entities.Contributor, len(book.GetContributors()))
+	for i, contributor := range book.GetContributors() {
+		contributors[i] = &entities.Contributor{
+			Name: contributor.GetName(),
+			Role: toEntityContributorRole(contributor.GetRole()),
+		}
+	}
+
+	return contributors
+}
+
+// toEntityContributorRole returns an empty role for unspecified roles, which is rejected by the domain.
+func toEntityContributorRole(role v1.Contributor_Role) entities.ContributorRole {
+	switch role {
+	case v1.Contributor_AUTHOR:
+		return entities.ContributorRoleAuthor
+	case v1.Contributor_EDITOR:
+		return entities.ContributorRoleEditor
+	case v1.Contributor_TRANSLATOR:
+		return entities.ContributorRoleTranslator
+	case v1.Contributor_ILLUSTRATOR:
+		return entities.ContributorRoleIllustrator
+	}
+
+	return ""
+}
+
+func toProtoContributors(contributors []*entities.Contributor) []*v1.Contributor {
+	pContributors := make([]*v1.Contributor, len(contributors))
+	for i, contributor := range contributors {
+		pContributors[i] = &v1.Contributor{
+			Name: contributor.Name,
+			Role: toProtoContributorRole(contributor.Role),
+		}
+	}
+
+	return pContributors
+}
+
+func toProtoContributorRole(role entities.ContributorRole) v1.Contributor_Role {
+	switch role {
+	case entities.ContributorRoleAuthor:
+		return v1.Contributor_AUTHOR
+	case entities.ContributorRoleEditor:
+		return v1.Contributor_EDITOR
+	case entities.ContributorRoleTranslator:
+		return v1.Contributor_TRANSLATOR
+	case entities.ContributorRoleIllustrator:
+		return v1.Contributor_ILLUSTRATOR
+	}
+
+	return v1.Contributor_ROLE_UNSPECIFIED
+}
+
+func toProtoSuggestionField(field entities.SuggestionField) v1.BookSuggestion_Field {
+	switch field {
+	case entities.SuggestionFieldTitle:
+		return v1.BookSuggestion_TITLE
+	case entities.SuggestionFieldAuthor:
+		return v1.BookSuggestion_AUTHOR
+	}
+
+	return v1.BookSuggestion_FIELD_UNSPECIFIED
+}
+
+// toProtoOptionalTime returns nil for zero time, so it is absent in the response.
+func toProtoOptionalTime(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
+
+// acquisitionDateLayout is the ISO 8601 calendar date format of the copy acquisition date.
+const acquisitionDateLayout = "2006-01-02"
+
+// copyAcquisitionDate returns the acquisition date at UTC midnight, which is zero if date is empty.
+func copyAcquisitionDate(field, date string) (time.Time, error) {
+	if date == "" {
+		return time.Time{}, nil
+	}
+
+	acquisitionDate, err := time.Parse(acquisitionDateLayout, date)
+	if err != nil {
+		return time.Time{}, api.InvalidArgument(&domainErrors.BadRequestError{ //nolint:wrapcheck
+			InvalidField: field,
+			Details:      fmt.Sprintf("%s must be a date formatted as YYYY-MM-DD", field),
+		})
+	}
+
+	return acquisitionDate, nil
+}
+
+func toProtoCopy(bookCopy *entities.Copy) *v1.Copy {
+	acquisitionDate := ""
+	if !bookCopy.AcquisitionDate.IsZero() {
+		acquisitionDate = bookCopy.AcquisitionDate.Format(acquisitionDateLayout)
+	}
+
+	return &v1.Copy{
+		Name:            copyResourceName(bookCopy),
+		Barcode:         bookCopy.Barcode,
+		AcquisitionDate: acquisitionDate,
+		Condition:       toProtoCopyCondition(bookCopy.Condition),
+		Status:          toProtoCopyStatus(bookCopy.Status),
+		CreateTime:      timestamppb.New(bookCopy.CreateTime),
+		UpdateTime:      timestamppb.New(bookCopy.UpdateTime),
+	}
+}
+
+func copyResourceName(bookCopy *entities.Copy) string {
+	return resourcename.Copy{Shelf: bookCopy.ShelfName, Book: bookCopy.BookName, Copy: bookCopy.Name}.String()
+}
+
+func toEntityCopyCondition(condition v1.Copy_Condition) entities.CopyCondition {
+	switch condition {
+	case v1.Copy_NEW:
+		return entities.CopyConditionNew
+	case v1.Copy_GOOD:
+		return entities.CopyConditionGood
+	case v1.Copy_FAIR:
+		return entities.CopyConditionFair
+	case v1.Copy_POOR:
+		return entities.CopyConditionPoor
+	case v1.Copy_DAMAGED:
+		return entities.CopyConditionDamaged
+	}
+
+	return ""
+}
+
+func toProtoCopyCondition(condition entities.CopyCondition) v1.Copy_Condition {
+	switch condition {
+	case entities.CopyConditionNew:
+		return v1.Copy_NEW
+	case entities.CopyConditionGood:
+		return v1.Copy_GOOD
+	case entities.CopyConditionFair:
+		return v1.Copy_FAIR
+	case entities.CopyConditionPoor:
+		return v1.Copy_POOR
+	case entities.CopyConditionDamaged:
+		return v1.Copy_DAMAGED
+	}
+
+	return v1.Copy_CONDITION_UNSPECIFIED
+}
+
+func toEntityCopyStatus(status v1.Copy_Status) entities.CopyStatus {
+	switch status {
+	case v1.Copy_AVAILABLE:
+		return entities.CopyStatusAvailable
+	case v1.Copy_ON_LOAN:
+		return entities.CopyStatusOnLoan
+	case v1.Copy_IN_REPAIR:
+		return entities.CopyStatusInRepair
+	case v1.Copy_LOST:
+		return entities.CopyStatusLost
+	}
+
+	return ""
+}
+
+func toProtoCopyStatus(status entities.CopyStatus) v1.Copy_Status {
+	switch status {
+	case entities.CopyStatusAvailable:
+		return v1.Copy_AVAILABLE
+	case entities.CopyStatusOnLoan:
+		return v1.Copy_ON_LOAN
+	case entities.CopyStatusInRepair:
+		return v1.Copy_IN_REPAIR
+	case entities.CopyStatusLost:
+		return v1.Copy_LOST
+	}
+
+	return v1.Copy_STATUS_UNSPECIFIED
+}
+
+func toProtoAuthor(author *entities.Author) *v1.Author {
+	return &v1.Author{
+		Name:        authorResourceName(author.Name),
+		DisplayName: author.DisplayName,
+		CreateTime:  timestamppb.New(author.CreateTime),
+		UpdateTime:  timestamppb.New(author.UpdateTime),
+	}
+}
+
+// authorResourceName returns the resource name of the author ID, which is empty if there is no author.
+func authorResourceName(authorName string) string {
+	if authorName == "" {
+		return ""
+	}
+
+	return resourcename.Author{Author: authorName}.String()
+}
+
+func toProtoSeries(series *entities.Series) *v1.Series {
+	return &v1.Series{
+		Name:        seriesResourceName(series.Name),
+		DisplayName: series.DisplayName,
+		VolumeCount: int32(series.VolumeCount),
+		CreateTime:  timestamppb.New(series.CreateTime),
+		UpdateTime:  timestamppb.New(series.UpdateTime),
+	}
+}
+
+// seriesResourceName returns the resource name of the series ID, which is empty if there is no series.
+func seriesResourceName(seriesName string) string {
+	if seriesName == "" {
+		return ""
+	}
+
+	return resourcename.Series{Series: seriesName}.String()
+}
+
+func toProtoWork(work *entities.Work) *v1.Work {
+	return &v1.Work{
+		Name:        workResourceName(work.Name),
+		DisplayName: work.DisplayName,
+		CreateTime:  timestamppb.New(work.CreateTime),
+		UpdateTime:  timestamppb.New(work.UpdateTime),
+	}
+}
+
+// workResourceName returns the resource name of the work ID, which is empty if there is no work.
+func workResourceName(workName string) string {
+	if workName == "" {
+		return ""
+	}
+
+	return resourcename.Work{Work: workName}.String()
+}
+
+func bookResourceName(book *entities.Book) string {
+	return fmt.Sprintf("%s/books/%s", shelfResourceName(book.Shelf), book.Name)
+}
+
+func toProtoShelf(shelf *entities.Shelf) *v1.Shelf {
+	if shelf == nil {
+		return nil
+	}
+
+	return &v1.Shelf{
+		Name:        shelfResourceName(shelf),
+		CreateTime:  timestamppb.New(shelf.CreateTime),
+		UpdateTime:  timestamppb.New(shelf.UpdateTime),
+		DisplayName: shelf.DisplayName,
+		Labels:      shelf.Labels,
+	}
+}
+
+// toProtoSearchFacets converts the domain facets, with shelf values as shelf resource names.
+func toProtoSearchFacets(facets []*entities.Facet) []*v1.SearchFacet {
+	pFacets := make([]*v1.SearchFacet, len(facets))
+
+	for i, facet := range facets {
+		pBuckets := make([]*v1.SearchFacet_Bucket, len(facet.Buckets))
+
+		for j, bucket := range facet.Buckets {
+			value := bucket.Value
+			if facet.Field == "shelf" {
+				value = resourcename.Shelf{Shelf: value}.String()
+			}
+
+			pBuckets[j] = &v1.SearchFacet_Bucket{Value: value, Count: int32(bucket.Count)}
+		}
+
+		pFacets[i] = &v1.SearchFacet{Field: facet.Field, Buckets: pBuckets}
+	}
+
+	return pFacets
+}
+
+func shelfResourceName(shelf *entities.Shelf) string {
+	return fmt.Sprintf("shelves/%s", shelf.Name)
+}
+
+// toLongRunningOperation converts the domain operation into a long-running operation.
+// If the operation failed, details are attached to its error status.
+func toLongRunningOperation(
+	name string,
+	operation *entities.Operation,
+	details api.Details,
+) *longrunning.Operation {
+	metadata, _ := anypb.New(&v1.Operation{
+		Name:       name,
+		Stage:      operation.Stage,
+		Percentage: uint32(operation.Percentage),
+	})
+
+	longRunningOperation := &longrunning.Operation{
+		Name:     operation.Name,
+		Metadata: metadata,
+		Done:     operation.Finished(),
+		Result:   nil,
+	}
+
+	if operation.Error != nil {
+		err := api.GRPCError(operation.Error, details)
+
+		longRunningOperation.Result = &longrunning.Operation_Error{
+			Error: status.Convert(err).Proto(),
+		}
+	}
+
+	return longRunningOperation
+}
+
+func createShelfOperationDetails(operation *entities.Operation) api.Details {
+	return api.Details{
+		codes.AlreadyExists: {&errdetails.ResourceInfo{
+			ResourceType: "shelf",
+			ResourceName: operation.ResourceName(),
+			Owner:        "library",
+			Description:  "the shelf already exists in the library",
+		}},
+	}
+}
+
+func deleteShelfOperationDetails(shelfName string) api.Details {
+	return api.Details{
+		codes.NotFound: {&errdetails.ResourceInfo{
+			ResourceType: "shelf",
+			ResourceName: shelfResourceName(&entities.Shelf{Name: shelfName}), //nolint:exhaustivestruct
+			Owner:        "library",
+			Description:  "the shelf was removed from the library by another request",
+		}},
+	}
 }
@@ -1,16 +1,27 @@
 package v1
 
 import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
 	"encoding/base64"
-	"strconv"
+	"encoding/binary"
+	"encoding/json"
+	"strings"
+	"time"
 
+	"github.com/Henrod/library/domain/pagination"
 	"go.uber.org/zap"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-const defaultPageSize = 10
+const (
+	defaultPageSize = 10
+	maxPageSize     = 1000
+	pageTokenTTL    = 24 * time.Hour
+)
 
 func errInvalidPageToken() error {
 	errStatus, _ := status.New(codes.InvalidArgument, "invalid page token").WithDetails(&errdetails.BadRequest{
@@ -23,44 +34,156 @@ func errInvalidPageToken() error {
 	return errStatus.Err() //nolint:wrapcheck
 }
 
-func getPageOffset(log *zap.SugaredLogger, pageToken string) (int, error) {
+// pageTokens encodes cursors into page tokens that can't be forged nor reused.
+//
+// A page token is "<payload>.<signature>", both base64 encoded, where the payload has the cursor,
+// the hash of the request that returned the token and the token expiration time,
+// and the signature is the payload HMAC-SHA256 with the server key.
+type pageTokens struct {
+	key []byte
+	log *zap.SugaredLogger
+}
+
+type pageTokenPayload struct {
+	Cursor      pagination.Cursor `json:"c"`
+	RequestHash []byte            `json:"r"`
+	ExpireTime  int64             `json:"e"`
+}
+
+// requestHash identifies the list request parameters, except the page token.
+// Lengths are written before each parameter, so different parameters never have the same hash.
+func requestHash(method string, params ...string) []byte {
+	hash := sha256.New()
+
+	for _, param := range append([]string{method}, params...) {
+		_ = binary.Write(hash, binary.BigEndian, uint64(len(param)))
+		hash.Write([]byte(param))
+	}
+
+	return hash.Sum(nil)
+}
+
+func (p *pageTokens) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, p.key)
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
+
+// cursor decodes the page token into the cursor of the last item of the previous page.
+// The token must have been returned for a request with the same hash and not be expired.
+// Empty page token returns nil cursor, which means the first page.
+func (p *pageTokens) cursor(pageToken string, hash []byte) (pagination.Cursor, error) {
 	if pageToken == "" {
-		return 0, nil
+		return nil, nil
+	}
+
+	log := p.log.With(zap.String("page_token", pageToken))
+
+	parts := strings.Split(pageToken, ".")
+	if len(parts) != 2 {
+		log.Error("page_token is not signed")
+
+		return nil, errInvalidPageToken()
 	}
 
-	bPageToken, err := base64.StdEncoding.DecodeString(pageToken)
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
 	if err != nil {
-		log.With(
-			zap.Error(err),
-			zap.String("page_token", pageToken),
-		).Error("failed to decode base64 page_token")
+		log.With(zap.Error(err)).Error("failed to decode base64 page_token payload")
 
-		return 0, errInvalidPageToken()
+		return nil, errInvalidPageToken()
 	}
 
-	pageOffset, err := strconv.Atoi(string(bPageToken))
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
 	if err != nil {
-		log.With(
-			zap.Error(err),
-			zap.ByteString("page_token", bPageToken),
-		).Error("failed to decode base64 page_token")
+		log.With(zap.Error(err)).Error("failed to decode base64 page_token signature")
 
-		return 0, errInvalidPageToken()
+		return nil, errInvalidPageToken()
 	}
 
-	return pageOffset, nil
+	if !hmac.Equal(signature, p.sign(payload)) {
+		log.Error("page_token signature doesn't match")
+
+		return nil, errInvalidPageToken()
+	}
+
+	var decoded pageTokenPayload
+	if err := json.Unmarshal(payload, &decoded); err != nil || decoded.Cursor == nil {
+		log.With(zap.Error(err)).Error("failed to decode page_token payload")
+
+		return nil, errInvalidPageToken()
+	}
+
+	if time.Now().Unix() > decoded.ExpireTime {
+		log.Error("page_token is expired")
+
+		return nil, errInvalidPageToken()
+	}
+
+	if !bytes.Equal(decoded.RequestHash, hash) {
+		log.Error("page_token was returned for a different request")
+
+		return nil, errInvalidPageToken()
+	}
+
+	return decoded.Cursor, nil
+}
+
+// nextPageToken encodes the cursor of the last item of the page for the request with hash.
+// Nil cursor returns empty token, which means there are no more pages.
+func (p *pageTokens) nextPageToken(cursor pagination.Cursor, hash []byte) (string, error) {
+	if cursor == nil {
+		return "", nil
+	}
+
+	payload, err := json.Marshal(pageTokenPayload{
+		Cursor:      cursor,
+		RequestHash: hash,
+		ExpireTime:  time.Now().Add(pageTokenTTL).Unix(),
+	})
+	if err != nil {
+		p.log.With(zap.Error(err)).Error("failed to encode page_token payload")
+
+		return "", status.Errorf(codes.Internal, "internal error") //nolint:wrapcheck
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(p.sign(payload)), nil
 }
 
-func getPageSize(pageSize int32) int {
-	if pageSize == 0 {
-		return defaultPageSize
+// getPageSize returns the default page size if pageSize is empty,
+// and coerces it to the maximum page size if it is bigger.
+func getPageSize(pageSize int32) (int, error) {
+	switch {
+	case pageSize < 0:
+		errStatus, _ := status.New(codes.InvalidArgument, "invalid page size").WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+				Field:       "page_size",
+				Description: "page_size must not be negative",
+			}},
+		})
+
+		return 0, errStatus.Err() //nolint:wrapcheck
+	case pageSize == 0:
+		return defaultPageSize, nil
+	case pageSize > maxPageSize:
+		return maxPageSize, nil
 	}
 
-	return int(pageSize)
+	return int(pageSize), nil
 }
 
-func getNextIntPageToken(next int) string {
-	bNext := []byte(strconv.Itoa(next))
+func getSkip(skip int32) (int, error) {
+	if skip < 0 {
+		errStatus, _ := status.New(codes.InvalidArgument, "invalid skip").WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+				Field:       "skip",
+				Description: "skip must not be negative",
+			}},
+		})
+
+		return 0, errStatus.Err() //nolint:wrapcheck
+	}
 
-	return base64.StdEncoding.EncodeToString(bNext)
+	return int(skip), nil
 }
@@ -0,0 +1,187 @@
+package v1
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Henrod/library/domain/pagination"
+)
+
+func newTestPageTokens() *pageTokens {
+	return &pageTokens{key: []byte("test-key"), log: zap.NewNop().Sugar()}
+}
+
+func listBooksHash(filter, orderBy, pageSize string) []byte {
+	return requestHash("ListBooks", "shelves/shelf1", filter, orderBy, "false", pageSize)
+}
+
+// signedPageToken encodes payload into a page token signed with the key of tokens.
+func signedPageToken(t *testing.T, tokens *pageTokens, payload pageTokenPayload) string {
+	t.Helper()
+
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("failed to encode payload: %v", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(encoded) + "." +
+		base64.RawURLEncoding.EncodeToString(tokens.sign(encoded))
+}
+
+func TestPageTokensRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tokens := newTestPageTokens()
+	hash := listBooksHash(`author = "Henrod"`, "title", "10")
+	cursor := pagination.Cursor{"Dune", "shelves/shelf1/books/dune"}
+
+	token, err := tokens.nextPageToken(cursor, hash)
+	if err != nil {
+		t.Fatalf("nextPageToken(%v) returned %v", cursor, err)
+	}
+
+	got, err := tokens.cursor(token, hash)
+	if err != nil {
+		t.Fatalf("cursor(%q) returned %v", token, err)
+	}
+
+	if !reflect.DeepEqual(got, cursor) {
+		t.Errorf("cursor(%q) = %v, want %v", token, got, cursor)
+	}
+}
+
+func TestPageTokensEmpty(t *testing.T) {
+	t.Parallel()
+
+	tokens := newTestPageTokens()
+
+	token, err := tokens.nextPageToken(nil, listBooksHash("", "", "10"))
+	if err != nil || token != "" {
+		t.Errorf("nextPageToken(nil) = %q, %v, want empty token", token, err)
+	}
+
+	cursor, err := tokens.cursor("", listBooksHash("", "", "10"))
+	if err != nil || cursor != nil {
+		t.Errorf("cursor(\"\") = %v, %v, want nil cursor", cursor, err)
+	}
+}
+
+func TestPageTokensInvalid(t *testing.T) {
+	t.Parallel()
+
+	tokens := newTestPageTokens()
+	hash := listBooksHash(`author = "Henrod"`, "title", "10")
+
+	token, err := tokens.nextPageToken(pagination.Cursor{"Dune", "shelves/shelf1/books/dune"}, hash)
+	if err != nil {
+		t.Fatalf("nextPageToken returned %v", err)
+	}
+
+	parts := strings.Split(token, ".")
+
+	tamperedPayload, err := json.Marshal(pageTokenPayload{
+		Cursor:      pagination.Cursor{"Zorba", "shelves/shelf1/books/zorba"},
+		RequestHash: hash,
+		ExpireTime:  time.Now().Add(pageTokenTTL).Unix(),
+	})
+	if err != nil {
+		t.Fatalf("failed to encode payload: %v", err)
+	}
+
+	otherTokens := &pageTokens{key: []byte("other-key"), log: zap.NewNop().Sugar()}
+
+	otherKeyToken, err := otherTokens.nextPageToken(pagination.Cursor{"Dune", "shelves/shelf1/books/dune"}, hash)
+	if err != nil {
+		t.Fatalf("nextPageToken returned %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		pageToken string
+		hash      []byte
+	}{
+		{
+			name:      "not signed",
+			pageToken: parts[0],
+			hash:      hash,
+		},
+		{
+			name:      "not base64",
+			pageToken: "not base64!." + parts[1],
+			hash:      hash,
+		},
+		{
+			name:      "tampered payload",
+			pageToken: base64.RawURLEncoding.EncodeToString(tamperedPayload) + "." + parts[1],
+			hash:      hash,
+		},
+		{
+			name:      "tampered signature",
+			pageToken: parts[0] + "." + base64.RawURLEncoding.EncodeToString([]byte("signature")),
+			hash:      hash,
+		},
+		{
+			name:      "signed with another key",
+			pageToken: otherKeyToken,
+			hash:      hash,
+		},
+		{
+			name: "expired",
+			pageToken: signedPageToken(t, tokens, pageTokenPayload{
+				Cursor:      pagination.Cursor{"Dune", "shelves/shelf1/books/dune"},
+				RequestHash: hash,
+				ExpireTime:  time.Now().Add(-time.Minute).Unix(),
+			}),
+			hash: hash,
+		},
+		{
+			name:      "different filter",
+			pageToken: token,
+			hash:      listBooksHash(`author = "Other"`, "title", "10"),
+		},
+		{
+			name:      "different order_by",
+			pageToken: token,
+			hash:      listBooksHash(`author = "Henrod"`, "title desc", "10"),
+		},
+		{
+			name:      "different page_size",
+			pageToken: token,
+			hash:      listBooksHash(`author = "Henrod"`, "title", "20"),
+		},
+		{
+			name:      "different method",
+			pageToken: token,
+			hash:      requestHash("ListShelves", `author = "Henrod"`, "title", "10"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cursor, err := tokens.cursor(tt.pageToken, tt.hash)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("cursor(%q) = %v, %v, want INVALID_ARGUMENT", tt.pageToken, cursor, err)
+			}
+		})
+	}
+}
+
+func TestRequestHash(t *testing.T) {
+	t.Parallel()
+
+	// Lengths are hashed with the parameters, so moving characters between parameters changes the hash.
+	if reflect.DeepEqual(requestHash("ListBooks", "ab", "c"), requestHash("ListBooks", "a", "bc")) {
+		t.Error(`requestHash("ab", "c") = requestHash("a", "bc"), want different hashes`)
+	}
+}